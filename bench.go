@@ -0,0 +1,305 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// reflectCopyFunc deep copies any value with reflect, as the baseline of the
+// generated benchmark. Unexported fields are shared, as they can't be set.
+const reflectCopyFunc = `// benchmarkReflectCopy deep copies v with reflect, as a baseline for the
+// generated copiers.
+func benchmarkReflectCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.New(v.Type().Elem())
+		cp.Elem().Set(benchmarkReflectCopy(v.Elem()))
+		return cp
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(benchmarkReflectCopy(v.Elem()))
+		return cp
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(benchmarkReflectCopy(v.Index(i)))
+		}
+		return cp
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(iter.Key(), benchmarkReflectCopy(iter.Value()))
+		}
+		return cp
+	case reflect.Array:
+		cp := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(benchmarkReflectCopy(v.Index(i)))
+		}
+		return cp
+	case reflect.Struct:
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if cp.Field(i).CanSet() {
+				cp.Field(i).Set(benchmarkReflectCopy(v.Field(i)))
+			}
+		}
+		return cp
+	default:
+		return v
+	}
+}`
+
+// benchmarkFile returns a test file of the package of the generated content,
+// with a BenchmarkGeneratedVsReflect benchmark comparing each of its copy
+// methods, or functions, of the given name, to the reflect based
+// benchmarkReflectCopy. The copied values are built from the types of the
+// package, as by fixture, which are qualified as by the generated code. Generic
+// types are left out, as they would have to be instantiated.
+func (a *app) benchmarkFile(generated []byte) ([]byte, error) {
+	pkg, method := a.pkg, a.copyMethod()
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "generated.go", generated, 0)
+	if err != nil {
+		return nil, fmt.Errorf("parsing generated file: %v", err)
+	}
+
+	imports := map[string]string{"reflect": "reflect", "testing": "testing"}
+	fix := &fixture{pkg: pkg, qualifier: a.qualifier(f.Name.Name, imports)}
+	var vars, runs bytes.Buffer
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Type.TypeParams != nil {
+			continue
+		}
+
+		var recv ast.Expr
+		switch {
+		case fn.Recv != nil && fn.Name.Name == method:
+			recv = fn.Recv.List[0].Type
+		case fn.Recv == nil && strings.HasPrefix(fn.Name.Name, method) && fn.Type.Params.NumFields() == 1:
+			recv = fn.Type.Params.List[0].Type
+		default:
+			continue
+		}
+
+		star, ok := recv.(*ast.StarExpr)
+		if ok {
+			recv = star.X
+		}
+		ident, ok := recv.(*ast.Ident)
+		if !ok {
+			// Generic or qualified types.
+			continue
+		}
+
+		name := "benchmark" + ident.Name
+		call := name + "." + method + "()"
+		if fn.Recv == nil {
+			arg := name
+			if star != nil {
+				arg = "&" + name
+			}
+			call = fn.Name.Name + "(" + arg + ")"
+		}
+
+		value, ok := "", false
+		if pkg != nil {
+			if tn, isType := pkg.Scope().Lookup(ident.Name).(*types.TypeName); isType {
+				value, ok = fix.expr(tn.Type(), nil)
+			}
+		}
+		if ok {
+			fmt.Fprintf(&vars, "%s = %s\n", name, value)
+		} else {
+			fmt.Fprintf(&vars, "%s %s\n", name, ident.Name)
+		}
+		fmt.Fprintf(&runs, `b.Run("%s/generated", func(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = %s
+	}
+})
+b.Run("%s/reflect", func(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = benchmarkReflectCopy(reflect.ValueOf(%s)).Interface()
+	}
+})
+`, ident.Name, call, ident.Name, name)
+	}
+
+	if vars.Len() == 0 {
+		return nil, fmt.Errorf("no %s method of a non-generic type of the package to benchmark", method)
+	}
+
+	fns := [][]byte{
+		[]byte("// The benchmarked values have a member in each of their slices and maps,\n// and their pointers allocated.\nvar (\n" + vars.String() + ")"),
+		[]byte("// BenchmarkGeneratedVsReflect compares the generated copiers to a reflect\n// based one.\nfunc BenchmarkGeneratedVsReflect(b *testing.B) {\n" + runs.String() + "}"),
+		[]byte(reflectCopyFunc),
+	}
+
+	return generateFile(f.Name.Name, a.license, imports, a.pkgNames, fns, false)
+}
+
+// fixture builds the values benchmarked, of the types of pkg, qualified by
+// qualifier.
+type fixture struct {
+	pkg       *types.Package
+	qualifier types.Qualifier
+}
+
+// expr returns the expression of a value of the type with a member in each of
+// its slices and maps, and its pointers allocated, or false if left zero. The
+// named types already on the path are left zero, as are interfaces and funcs,
+// whose values are unknown, and the types that can't be named in pkg.
+func (f *fixture) expr(t types.Type, path []types.Type) (string, bool) {
+	t = types.Unalias(t)
+	if !f.nameable(t) {
+		return "", false
+	}
+
+	named, _ := t.(*types.Named)
+	if named != nil {
+		for _, seen := range path {
+			if types.Identical(seen, t) {
+				return "", false
+			}
+		}
+		path = append(path, t)
+	}
+
+	name := f.typeString(t)
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		var v string
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			v = "true"
+		case u.Info()&types.IsString != 0:
+			v = `"a"`
+		case u.Info()&types.IsNumeric != 0:
+			v = "1"
+		default:
+			return "", false
+		}
+		if named != nil {
+			v = name + "(" + v + ")"
+		}
+		return v, true
+	case *types.Struct:
+		var fields []string
+		for i := 0; i < u.NumFields(); i++ {
+			field := u.Field(i)
+			if field.Name() == "_" || (!field.Exported() && field.Pkg() != f.pkg) {
+				continue
+			}
+			if v, ok := f.expr(field.Type(), path); ok {
+				fields = append(fields, field.Name()+": "+v+",\n")
+			}
+		}
+		if len(fields) == 0 {
+			return "", false
+		}
+		return name + "{\n" + strings.Join(fields, "") + "}", true
+	case *types.Slice:
+		v, ok := f.expr(u.Elem(), path)
+		if !ok {
+			return "make(" + name + ", 1)", true
+		}
+		return name + "{" + v + "}", true
+	case *types.Array:
+		v, ok := f.expr(u.Elem(), path)
+		if !ok || u.Len() == 0 {
+			return "", false
+		}
+		return name + "{" + v + "}", true
+	case *types.Map:
+		k, ok := f.expr(u.Key(), path)
+		if !ok {
+			k = "*new(" + f.typeString(u.Key()) + ")"
+		}
+		v, ok := f.expr(u.Elem(), path)
+		if !ok {
+			v = "*new(" + f.typeString(u.Elem()) + ")"
+		}
+		return name + "{" + k + ": " + v + "}", true
+	case *types.Pointer:
+		elem := f.typeString(u.Elem())
+		v, ok := f.expr(u.Elem(), path)
+		switch {
+		case !ok:
+			return "new(" + elem + ")", true
+		case named == nil && strings.HasPrefix(v, elem+"{"):
+			return "&" + v, true
+		default:
+			return "func() " + name + " {\nvar v " + elem + " = " + v + "\nreturn &v\n}()", true
+		}
+	case *types.Chan:
+		return "make(" + name + ", 1)", true
+	default:
+		return "", false
+	}
+}
+
+// typeString returns the type as named in pkg.
+func (f *fixture) typeString(t types.Type) string {
+	return types.TypeString(t, f.qualifier)
+}
+
+// nameable reports whether the type can be named in pkg: whether it is made
+// of exported types, or of those of pkg.
+func (f *fixture) nameable(t types.Type) bool {
+	switch t := types.Unalias(t).(type) {
+	case *types.Named:
+		if obj := t.Obj(); obj.Pkg() != nil && obj.Pkg() != f.pkg && !obj.Exported() {
+			return false
+		}
+		for i := 0; i < t.TypeArgs().Len(); i++ {
+			if !f.nameable(t.TypeArgs().At(i)) {
+				return false
+			}
+		}
+	case *types.Pointer:
+		return f.nameable(t.Elem())
+	case *types.Slice:
+		return f.nameable(t.Elem())
+	case *types.Array:
+		return f.nameable(t.Elem())
+	case *types.Chan:
+		return f.nameable(t.Elem())
+	case *types.Map:
+		return f.nameable(t.Key()) && f.nameable(t.Elem())
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			field := t.Field(i)
+			if (!field.Exported() && field.Pkg() != f.pkg) || !f.nameable(field.Type()) {
+				return false
+			}
+		}
+	case *types.TypeParam:
+		return false
+	}
+
+	return true
+}
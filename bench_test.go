@@ -0,0 +1,173 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_benchmarkFile(t *testing.T) {
+	a := &app{}
+	generated, err := a.run("./testdata", typesVal{"Foo", "GenericTree", "Frame"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := a.benchmarkFile(generated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got = normalizeComment(got)
+	want := strings.Replace(BenchmarkFile, "{{reflectCopyFunc}}", reflectCopyFunc, 1)
+	if diff := cmp.Diff(string(got), want); diff != "" {
+		t.Errorf("benchmarkFile() diff = %s", diff)
+	}
+
+	// The methods of the types are those already generated in testdata.
+	if err := verifyCompiles("./testdata", "./testdata/deep_copy_bench_test.go", got, true); err != nil {
+		t.Error(err)
+	}
+}
+
+func Test_benchmarkFileRecursive(t *testing.T) {
+	a := &app{}
+	generated, err := a.run("./testdata", typesVal{"BinaryTree", "Org"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := a.benchmarkFile(generated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The recursive members are allocated once, as zero values.
+	if !strings.Contains(string(got), "benchmarkBinaryTree = BinaryTree{") {
+		t.Errorf("benchmarked BinaryTree isn't built:\n%s", got)
+	}
+	if err := verifyCompiles("./testdata", "./testdata/deep_copy_bench_test.go", got, true); err != nil {
+		t.Error(err)
+	}
+}
+
+func Test_benchmarkFileSameNamedPackages(t *testing.T) {
+	a := &app{}
+	generated, err := a.run("./testdata", typesVal{"Lookup"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := a.benchmarkFile(generated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Both valpkg packages are imported, one of them with an alias.
+	if err := verifyCompiles("./testdata", "./testdata/deep_copy_bench_test.go", got, true); err != nil {
+		t.Error(err)
+	}
+}
+
+func Test_benchmarkFileFuncs(t *testing.T) {
+	a := &app{funcs: true, isPtrRecv: true}
+	generated, err := a.run("./testdata", typesVal{"Foo"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := a.benchmarkFile(generated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(got), "_ = DeepCopyFoo(&benchmarkFoo)") {
+		t.Errorf("benchmark doesn't call the DeepCopyFoo function:\n%s", got)
+	}
+}
+
+func Test_benchmarkFileGeneric(t *testing.T) {
+	a := &app{}
+	generated, err := a.run("./testdata", typesVal{"GenericTree"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := a.benchmarkFile(generated); err == nil {
+		t.Error("expected an error without a non-generic type to benchmark")
+	}
+}
+
+const BenchmarkFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	"reflect"
+	"testing"
+)
+
+// The benchmarked values have a member in each of their slices and maps,
+// and their pointers allocated.
+var (
+	benchmarkFoo = Foo{
+		Map: map[string]*Bar{"a": &Bar{
+			IntV:  1,
+			Slice: []string{"a"},
+		}},
+		ch: make(chan float32, 1),
+		baz: Baz{
+			String: "a",
+			StringPointer: func() *string {
+				var v string = "a"
+				return &v
+			}(),
+		},
+	}
+	benchmarkFrame = Frame{
+		Data: []byte{1},
+		Bars: []*Bar{&Bar{
+			IntV:  1,
+			Slice: []string{"a"},
+		}},
+		Header: FrameHeader{
+			Tags: []string{"a"},
+		},
+		Prev: &FrameHeader{
+			Tags: []string{"a"},
+		},
+		Indexes: map[string][]int{"a": []int{1}},
+	}
+)
+
+// BenchmarkGeneratedVsReflect compares the generated copiers to a reflect
+// based one.
+func BenchmarkGeneratedVsReflect(b *testing.B) {
+	b.Run("Foo/generated", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = benchmarkFoo.DeepCopy()
+		}
+	})
+	b.Run("Foo/reflect", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = benchmarkReflectCopy(reflect.ValueOf(benchmarkFoo)).Interface()
+		}
+	})
+	b.Run("Frame/generated", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = benchmarkFrame.DeepCopy()
+		}
+	})
+	b.Run("Frame/reflect", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = benchmarkReflectCopy(reflect.ValueOf(benchmarkFrame)).Interface()
+		}
+	})
+}
+
+{{reflectCopyFunc}}`
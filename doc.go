@@ -3,7 +3,9 @@
 // Given a package directory, and a type name that appears in that package, a
 // DeepCopy method will be generated, to create a deep copy of the type value.
 // Members of the type will also be copied deeply, recursively. If a member T
-// of the type has a method "DeepCopy() [*]T", that method will be reused.
+// of the type has a method "DeepCopy() [*]T", that method will be reused, as
+// well as the "DeepCopy" method declared by an interface, or by the interfaces
+// it embeds.
 // Multiple types can be specified for the given package, by adding more --type
 // parameters.
 //
@@ -11,8 +13,18 @@
 // boolean flag can be specified. The flag will also govern whether the return
 // type is a pointer as well.
 //
+// To copy into an existing value, the optional --into flag generates a
+// "DeepCopyInto(out *T)" method as well, to which DeepCopy delegates.
+//
+// The optional --func flag generates "DeepCopyT" functions instead of methods,
+// which --out-package allows declaring in another package.
+//
 // It might also be desirable to skip deeply copying certain fields, slice
 // members, or map members. To achieve that, selectors can be specified in the
 // optional comma-separated --skip flag. Multiple --skip flags can be
-// specified, to match the number of --type flags.
+// specified, to match the number of --type flags. Glob patterns of selectors
+// to skip in every type can be given with the --skip-all flag.
+//
+// Types of several packages can be listed, one "path.Type [skip=selectors]"
+// per line, in the file given to the --from flag, instead.
 package main
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"io"
+	"strconv"
+)
+
+// generateEqual returns the DeepEqual method of the type, comparing the
+// members the DeepCopy method copies element-wise, so that a fresh copy equals
+// its source.
+func (a *app) generateEqual(obj object, x, kind string, ptrRecv bool, imports map[string]string, generating []object) []byte {
+	var buf bytes.Buffer
+
+	ptr := ""
+	if ptrRecv {
+		ptr = "*"
+	}
+
+	fmt.Fprintf(&buf, `// %s reports whether %s%s is deeply equal to other
+%s {
+`, a.funcName(obj, "DeepEqual"), ptr, kind, a.funcDecl(obj, x, imports, "DeepEqual", "o "+ptr+kind, "other "+ptr+kind, "bool"))
+
+	left, right := "o", "other"
+	if ptrRecv {
+		buf.WriteString(`if o == nil || other == nil {
+	return o == other
+}
+`)
+		if _, ok := obj.Underlying().(*types.Struct); !ok {
+			left, right = deref(left), deref(right)
+		}
+	}
+
+	a.walkEqual(left, right, x, obj, &buf, imports, generating, nil, 0)
+	buf.WriteString("return true\n}")
+
+	return buf.Bytes()
+}
+
+// walkEqual writes the comparison of left and right of type m, returning false
+// as soon as they differ. Members are compared as walkType copies them: the
+// elements of slices, arrays and pointers, and the values of maps, are compared
+// instead of their addresses, and the keys of maps by value, as they are
+// shared. Channels and functions are left out, as they aren't copied as is.
+// Interfaces, members that can't be selected, and recursive types other than
+// those being generated are compared with reflect.DeepEqual.
+func (a *app) walkEqual(left, right, x string, m types.Type, w io.Writer, imports map[string]string, generating []object, path []types.Type, depth int) {
+	initial := depth == 0
+	if m == nil {
+		return
+	}
+	m = types.Unalias(m)
+
+	if !initial {
+		if obj := generatingType(m, generating); obj != nil {
+			a.writeDiffers(w, "!"+a.generatedEqual(obj, left, right, false))
+			return
+		}
+
+		for _, t := range path {
+			if types.Identical(t, m) {
+				a.writeDiffers(w, "!"+a.reflectEqual(left, right, imports))
+				return
+			}
+		}
+
+		if method := equalMethod(m); method != "" {
+			a.writeDiffers(w, "!"+selectField(left, method+"("+right+")"))
+			return
+		}
+	}
+
+	if v, ok := m.(*types.TypeParam); ok {
+		if types.Comparable(v) {
+			a.writeDiffers(w, left+" != "+right)
+		} else {
+			a.writeDiffers(w, "!"+a.reflectEqual(left, right, imports))
+		}
+		return
+	}
+
+	if _, ok := m.(*types.Named); ok {
+		path = append(path, m)
+	}
+
+	depth++
+	switch v := m.Underlying().(type) {
+	case *types.Basic:
+		a.writeDiffers(w, left+" != "+right)
+	case *types.Struct:
+		if named, ok := m.(*types.Named); ok && !a.isLocal(named.Obj().Pkg()) && hasUnexported(v) {
+			a.writeDiffers(w, "!"+a.reflectEqual(left, right, imports))
+			return
+		}
+
+		for i := 0; i < v.NumFields(); i++ {
+			field := v.Field(i)
+			// Locks, and fields left zero in the copy, aren't compared.
+			if field.Name() == "_" || isLock(field.Type()) || a.fieldTag(v, i) == "zero" {
+				continue
+			}
+
+			a.walkEqual(selectField(left, field.Name()), selectField(right, field.Name()), x, field.Type(), w, imports, generating, path, depth)
+		}
+	case *types.Array:
+		idx := "i"
+		if depth > 1 {
+			idx += strconv.Itoa(depth)
+		}
+
+		var b bytes.Buffer
+		a.walkEqual(index(left, idx), index(right, idx), x, v.Elem(), &b, imports, generating, path, depth)
+		if b.Len() > 0 {
+			fmt.Fprintf(w, "for %s := range %s {\n", idx, left)
+			b.WriteTo(w)
+			fmt.Fprintf(w, "}\n")
+		}
+	case *types.Slice:
+		idx := "i"
+		if depth > 1 {
+			idx += strconv.Itoa(depth)
+		}
+
+		a.writeLenDiffers(w, left, right, a.nilSliceAsEmpty)
+
+		var b bytes.Buffer
+		a.walkEqual(index(left, idx), index(right, idx), x, v.Elem(), &b, imports, generating, path, depth)
+		if b.Len() > 0 {
+			fmt.Fprintf(w, "for %s := range %s {\n", idx, left)
+			b.WriteTo(w)
+			fmt.Fprintf(w, "}\n")
+		}
+	case *types.Map:
+		key, val, other := "k", "v", "ov"
+		if depth > 1 {
+			key += strconv.Itoa(depth)
+			val += strconv.Itoa(depth)
+			other += strconv.Itoa(depth)
+		}
+
+		a.writeLenDiffers(w, left, right, a.nilMapAsEmpty)
+
+		var b bytes.Buffer
+		a.walkEqual(val, other, x, v.Elem(), &b, imports, generating, path, depth)
+		if b.Len() > 0 {
+			fmt.Fprintf(w, `for %s, %s := range %s {
+%s, ok := %s
+if !ok {
+	return false
+}
+`, key, val, left, other, index(right, key))
+		} else {
+			fmt.Fprintf(w, `for %s := range %s {
+if _, ok := %s; !ok {
+	return false
+}
+`, key, left, index(right, key))
+		}
+		b.WriteTo(w)
+		fmt.Fprintf(w, "}\n")
+	case *types.Pointer:
+		if obj := generatingType(v.Elem(), generating); obj != nil && a.ptrRecv(obj) {
+			a.writeDiffers(w, "!"+a.generatedEqual(obj, left, right, true))
+			return
+		}
+
+		fmt.Fprintf(w, `if (%s == nil) != (%s == nil) {
+	return false
+}
+`, left, right)
+
+		// The fields of pointed-to structs are selected through the pointers.
+		elemLeft, elemRight := left, right
+		if _, ok := v.Elem().Underlying().(*types.Struct); !ok || generatingType(v.Elem(), generating) != nil || equalMethod(v.Elem()) != "" {
+			elemLeft, elemRight = deref(left), deref(right)
+		}
+
+		var b bytes.Buffer
+		a.walkEqual(elemLeft, elemRight, x, v.Elem(), &b, imports, generating, path, depth)
+		if b.Len() > 0 {
+			fmt.Fprintf(w, "if %s != nil && %s != %s {\n", left, left, right)
+			b.WriteTo(w)
+			fmt.Fprintf(w, "}\n")
+		}
+	case *types.Interface:
+		a.writeDiffers(w, "!"+a.reflectEqual(left, right, imports))
+	}
+}
+
+// writeDiffers writes the statement returning false if the differs expression
+// holds.
+func (a *app) writeDiffers(w io.Writer, differs string) {
+	fmt.Fprintf(w, `if %s {
+	return false
+}
+`, differs)
+}
+
+// writeLenDiffers writes the statement returning false if the slices, or maps,
+// differ in length, or only one of them is nil, unless nil ones are copied as
+// empty.
+func (a *app) writeLenDiffers(w io.Writer, left, right string, nilAsEmpty bool) {
+	if nilAsEmpty {
+		fmt.Fprintf(w, `if len(%s) != len(%s) {
+	return false
+}
+`, left, right)
+	} else {
+		fmt.Fprintf(w, `if len(%s) != len(%s) || (%s == nil) != (%s == nil) {
+	return false
+}
+`, left, right, left, right)
+	}
+}
+
+// generatedEqual returns the call of the DeepEqual method generated for the
+// type, comparing left and right, which are pointers if pointer is set, as are
+// the receivers of the method then.
+func (a *app) generatedEqual(obj object, left, right string, pointer bool) string {
+	if !pointer && a.ptrRecv(obj) {
+		if a.funcs {
+			left = "&" + left
+		}
+		right = "&" + right
+	}
+
+	if a.funcs {
+		return fmt.Sprintf("%s(%s, %s)", a.funcName(obj, "DeepEqual"), left, right)
+	}
+
+	return selectField(left, "DeepEqual("+right+")")
+}
+
+// reflectEqual returns the reflect.DeepEqual comparison of left and right.
+func (a *app) reflectEqual(left, right string, imports map[string]string) string {
+	imports["reflect"] = "reflect"
+
+	return fmt.Sprintf("reflect.DeepEqual(%s, %s)", left, right)
+}
+
+// equalMethod returns the name of the method of the type comparing it with a
+// value of the same type, either "DeepEqual" or "Equal", such as that of
+// time.Time, if it has one.
+func equalMethod(t types.Type) string {
+	t = types.Unalias(t)
+	if _, ok := t.(*types.Named); !ok {
+		return ""
+	}
+
+	ms := types.NewMethodSet(t)
+	for _, name := range []string{"DeepEqual", "Equal"} {
+		sel := ms.Lookup(nil, name)
+		if sel == nil {
+			continue
+		}
+
+		sig, ok := sel.Type().(*types.Signature)
+		if !ok || sig.Params().Len() != 1 || sig.Results().Len() != 1 {
+			continue
+		}
+
+		if types.Identical(sig.Params().At(0).Type(), t) && types.Identical(sig.Results().At(0).Type(), types.Typ[types.Bool]) {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// hasUnexported reports whether the struct has unexported fields, other than
+// blank ones.
+func hasUnexported(v *types.Struct) bool {
+	for i := 0; i < v.NumFields(); i++ {
+		if f := v.Field(i); !f.Exported() && f.Name() != "_" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// fromName is the name of the file generated in the directory of each
+// package, when the types are listed in a file.
+const fromName = "deep_copy_gen.go"
+
+// typeEntry is a type listed in a types file, with its skipped selectors.
+type typeEntry struct {
+	line  int
+	path  string
+	kind  string
+	skips skips
+}
+
+// packageOutput is the content generated for a package, and the file it is to
+// be written to.
+type packageOutput struct {
+	file    string
+	content []byte
+}
+
+// parseTypesFile parses the lines of a types file, each a type qualified by
+// the path of its package, such as "./pkg.Type", optionally followed by the
+// selectors to skip, such as "skip=Field,Slice[i]". Blank lines, and those
+// starting with a "#", are ignored.
+func parseTypesFile(r io.Reader) ([]typeEntry, error) {
+	var entries []typeEntry
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		path, kind := splitQualified(fields[0])
+		if path == "" || kind == "" {
+			return nil, fmt.Errorf("line %d: expected a type qualified by its package, got %q", n, fields[0])
+		}
+
+		entry := typeEntry{line: n, path: path, kind: kind}
+		for _, annotation := range fields[1:] {
+			selectors, ok := strings.CutPrefix(annotation, "skip=")
+			if !ok || selectors == "" {
+				return nil, fmt.Errorf("line %d: expected skip=selectors, got %q", n, annotation)
+			}
+
+			if entry.skips == nil {
+				entry.skips = skips{}
+			}
+			for _, sel := range strings.Split(selectors, ",") {
+				entry.skips[sel] = struct{}{}
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// runFrom generates the types of each package listed in the types file, in the
+// order the packages are first listed in. The content of each package is to be
+// written to a file in its directory. Types that can't be located are reported
+// with their line in the file.
+func (a *app) runFrom(name string, entries []typeEntry) ([]packageOutput, error) {
+	var paths []string
+	byPath := map[string][]typeEntry{}
+	for _, entry := range entries {
+		if _, ok := byPath[entry.path]; !ok {
+			paths = append(paths, entry.path)
+		}
+		byPath[entry.path] = append(byPath[entry.path], entry)
+	}
+
+	outputs := make([]packageOutput, 0, len(paths))
+	for _, path := range paths {
+		var types typesVal
+		var skips skipsVal
+		for _, entry := range byPath[path] {
+			types = append(types, entry.kind)
+			skips = append(skips, entry.skips)
+		}
+
+		dir, err := packageDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: package %q: %v", name, path, err)
+		}
+
+		// The file to be overwritten doesn't declare methods of its own.
+		pa := *a
+		pa.warnings = nil
+		pa.output = filepath.Join(dir, fromName)
+		b, err := pa.run(path, types, skips)
+		a.warnings = append(a.warnings, pa.warnings...)
+		if err != nil {
+			var le *locateError
+			if errors.As(err, &le) {
+				for _, entry := range byPath[path] {
+					if entry.kind == le.kind {
+						return nil, fmt.Errorf("%s:%d: %v", name, entry.line, err)
+					}
+				}
+			}
+
+			return nil, fmt.Errorf("%s: package %q: %v", name, path, err)
+		}
+
+		outputs = append(outputs, packageOutput{file: pa.output, content: b})
+	}
+
+	return outputs, nil
+}
+
+// packageDir returns the directory of the package of the path, its files are
+// generated in.
+func packageDir(path string) (string, error) {
+	cfg, pattern := loadConfig(path, false)
+	cfg.Mode = packages.NeedName | packages.NeedFiles
+	pkgs, err := packages.Load(cfg, pattern)
+	if err != nil {
+		return "", err
+	}
+	if len(pkgs) == 0 || len(pkgs[0].GoFiles) == 0 {
+		return "", errors.New("no Go file found")
+	}
+
+	return filepath.Dir(pkgs[0].GoFiles[0]), nil
+}
+
+// generateFrom generates the types listed in the named file, writing the
+// content of each package to its file, created with the mode, once all of
+// them are generated.
+func generateFrom(a *app, name string, mode os.FileMode, merge, verify bool) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	entries, err := parseTypesFile(f)
+	if err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+
+	outputs, err := a.runFrom(name, entries)
+	if err != nil {
+		return err
+	}
+
+	return writeOutputs(a, outputs, mode, merge, verify)
+}
+
+// isPackages reports whether the arguments are several packages, or a pattern
+// matching several, such as ./...
+func isPackages(args []string) bool {
+	return len(args) > 1 || strings.Contains(args[0], "...")
+}
+
+// runPackages generates the types in each package matched by the patterns, the
+// content of each package to be written to the named file in its directory.
+// Each package generates the types it declares, packages declaring none of
+// them, or no type matching them, are left out.
+func (a *app) runPackages(patterns []string, name string, types typesVal, skips skipsVal) ([]packageOutput, error) {
+	if filepath.Base(name) != name {
+		return nil, fmt.Errorf("-o %s: a file name is expected, generated in the directory of each package, when given several packages", name)
+	}
+
+	cfg := &packages.Config{Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("loading packages: %v", err)
+	}
+
+	var outputs []packageOutput
+	for _, p := range pkgs {
+		if len(p.GoFiles) == 0 {
+			continue
+		}
+
+		pTypes, pSkips := types, skips
+		if len(types) > 0 {
+			pTypes, pSkips = declaredTypes(p, types, skips)
+			if len(pTypes) == 0 {
+				continue
+			}
+		}
+
+		pa := *a
+		pa.warnings = nil
+		pa.output = filepath.Join(filepath.Dir(p.GoFiles[0]), name)
+		b, err := pa.run(filepath.Dir(p.GoFiles[0]), pTypes, pSkips)
+		a.warnings = append(a.warnings, pa.warnings...)
+		if err != nil {
+			var le *locateError
+			if errors.As(err, &le) {
+				continue
+			}
+
+			return nil, fmt.Errorf("package %q: %v", p.PkgPath, err)
+		}
+
+		outputs = append(outputs, packageOutput{file: pa.output, content: b})
+	}
+
+	if len(outputs) == 0 {
+		return nil, fmt.Errorf("no package of %s declares the types to generate", strings.Join(patterns, " "))
+	}
+
+	return outputs, nil
+}
+
+// declaredTypes filters the types, and their skips, down to the ones the
+// package declares, or that match a type it declares if a pattern.
+func declaredTypes(p *packages.Package, types typesVal, skips skipsVal) (typesVal, skipsVal) {
+	declared := map[string]bool{}
+	for _, f := range p.Syntax {
+		for _, decl := range f.Decls {
+			if g, ok := decl.(*ast.GenDecl); ok && g.Tok == token.TYPE {
+				for _, spec := range g.Specs {
+					declared[spec.(*ast.TypeSpec).Name.Name] = true
+				}
+			}
+		}
+	}
+
+	var pTypes typesVal
+	var pSkips skipsVal
+	for i, kind := range types {
+		base := strings.TrimPrefix(kind, "*")
+		if j := strings.IndexByte(base, '['); j >= 0 {
+			base = base[:j]
+		}
+
+		ok := declared[base]
+		if match := typePattern(base); match != nil {
+			for name := range declared {
+				if match(name) {
+					ok = true
+					break
+				}
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		pTypes = append(pTypes, kind)
+		if i < len(skips) {
+			pSkips = append(pSkips, skips[i])
+		}
+	}
+
+	return pTypes, pSkips
+}
+
+// generatePackages generates the types in each package matched by the
+// patterns, writing the content of each package to the named file in its
+// directory, created with the mode, once all of them are generated.
+func generatePackages(a *app, patterns []string, name string, types typesVal, skips skipsVal, mode os.FileMode, merge, verify bool) error {
+	outputs, err := a.runPackages(patterns, name, types, skips)
+	if err != nil {
+		return err
+	}
+
+	return writeOutputs(a, outputs, mode, merge, verify)
+}
+
+// writeOutputs writes the content generated for each package to its file,
+// created with the mode, merged into the existing one or verified first.
+func writeOutputs(a *app, outputs []packageOutput, mode os.FileMode, merge, verify bool) error {
+	for i := range outputs {
+		out := &outputs[i]
+		if merge {
+			existing, err := os.ReadFile(out.file)
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+
+			if out.content, err = mergeFile(existing, out.content); err != nil {
+				return fmt.Errorf("merging into %s: %v", out.file, err)
+			}
+		}
+
+		if verify {
+			if err := verifyCompiles(filepath.Dir(out.file), out.file, out.content, a.tests); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, out := range outputs {
+		if err := os.WriteFile(out.file, out.content, mode); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
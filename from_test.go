@@ -0,0 +1,180 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseTypesFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    []typeEntry
+		wantErr bool
+	}{
+		{
+			name: "types",
+			text: "# models\n./testdata.Foo skip=Slice,Map[k]\n\ngithub.com/globusdigital/deep-copy/testdata/generic.Box\n",
+			want: []typeEntry{
+				{line: 2, path: "./testdata", kind: "Foo", skips: skips{"Slice": {}, "Map[k]": {}}},
+				{line: 4, path: "github.com/globusdigital/deep-copy/testdata/generic", kind: "Box"},
+			},
+		},
+		{name: "unqualified type", text: "Foo\n", wantErr: true},
+		{name: "unknown annotation", text: "./testdata.Foo Slice\n", wantErr: true},
+		{name: "empty skip", text: "./testdata.Foo skip=\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTypesFile(strings.NewReader(tt.text))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTypesFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTypesFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runFrom(t *testing.T) {
+	entries, err := parseTypesFile(strings.NewReader("./testdata.Foo skip=Slice\n./testdata/generic.Box\n./testdata.Alpha\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := &app{quiet: true}
+	outputs, err := a.runFrom("types.txt", entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(outputs) != 2 {
+		t.Fatalf("runFrom() = %d outputs, want one per package", len(outputs))
+	}
+	for i, dir := range []string{"testdata", filepath.Join("testdata", "generic")} {
+		if want := filepath.Join(dir, fromName); !strings.HasSuffix(outputs[i].file, want) {
+			t.Errorf("output %d file = %s, want %s", i, outputs[i].file, want)
+		}
+	}
+
+	// The types of a package are generated together, whatever their order.
+	want, err := (&app{quiet: true}).run("./testdata", typesVal{"Foo", "Alpha"}, skipsVal{{"Slice": {}}, nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(outputs[0].content, want); diff != "" {
+		t.Errorf("runFrom() diff = %s", diff)
+	}
+
+	if len(a.warnings) != 1 {
+		t.Errorf("warnings = %v, want those of the generic package", a.warnings)
+	}
+}
+
+func Test_runFrom_regenerate(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod": "module example.com/regen\n\ngo 1.21\n",
+		"t.go":   "package regen\n\ntype T struct {\n\tS []int\n}\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries := []typeEntry{{line: 1, path: dir, kind: "T"}}
+
+	// The methods of the file generated first are generated again.
+	var counts []int
+	for i := 0; i < 2; i++ {
+		a := &app{quiet: true, genShallow: true, genEqual: true}
+		outputs, err := a.runFrom("types.txt", entries)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(a.warnings) != 0 {
+			t.Errorf("run %d warnings = %v", i, a.warnings)
+		}
+		if err := writeOutputs(a, outputs, 0644, false, false); err != nil {
+			t.Fatal(err)
+		}
+
+		content := string(outputs[0].content)
+		counts = append(counts, strings.Count(content, ") ShallowCopy()")+strings.Count(content, ") DeepEqual("))
+	}
+	if counts[0] != 2 || counts[1] != 2 {
+		t.Errorf("ShallowCopy and DeepEqual methods = %v, want 2 each run", counts)
+	}
+}
+
+func Test_runFrom_unresolved(t *testing.T) {
+	entries, err := parseTypesFile(strings.NewReader("./testdata.Foo\n\n./testdata.Missing\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := &app{quiet: true}
+	_, err = a.runFrom("types.txt", entries)
+	if err == nil || !strings.HasPrefix(err.Error(), "types.txt:3: ") {
+		t.Errorf("runFrom() error = %v, want one of line 3", err)
+	}
+}
+
+func Test_runPackages(t *testing.T) {
+	a := &app{quiet: true, all: true}
+	outputs, err := a.runPackages([]string{"./testdata/remote/..."}, "copy_gen.go", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dirs := []string{filepath.Join("remote", "keypkg"), filepath.Join("remote", "legacy", "valpkg"), filepath.Join("remote", "valpkg")}
+	if len(outputs) != len(dirs) {
+		t.Fatalf("runPackages() = %d outputs, want one per package", len(outputs))
+	}
+	for i, dir := range dirs {
+		if want := filepath.Join(dir, "copy_gen.go"); !strings.HasSuffix(outputs[i].file, want) {
+			t.Errorf("output %d file = %s, want %s", i, outputs[i].file, want)
+		}
+	}
+
+	// Packages declaring none of the types are left out.
+	a = &app{quiet: true}
+	outputs, err = a.runPackages([]string{"./testdata/remote/keypkg", "./testdata/remote/valpkg"}, fromName, typesVal{"Val"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputs) != 1 || !strings.Contains(string(outputs[0].content), "func (o Val) DeepCopy() Val") {
+		t.Errorf("runPackages() = %v, want the valpkg output alone", outputs)
+	}
+
+	if _, err := a.runPackages([]string{"./testdata/remote/..."}, fromName, typesVal{"Missing"}, nil); err == nil {
+		t.Error("expected an error for types declared by no package")
+	}
+
+	// Each package generates the types it declares.
+	outputs, err = a.runPackages([]string{"./testdata/remote/..."}, fromName, typesVal{"Key", "Val"}, skipsVal{{"Name": {}}, {"Tags": {}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputs) != len(dirs) {
+		t.Fatalf("runPackages() = %d outputs, want one per package", len(outputs))
+	}
+	for i, want := range []string{"func (o Key) DeepCopy() Key", "func (o Val) DeepCopy() Val", "func (o Val) DeepCopy() Val"} {
+		if content := string(outputs[i].content); !strings.Contains(content, want) {
+			t.Errorf("output %d = %s, want %s", i, content, want)
+		}
+	}
+	if content := string(outputs[1].content); strings.Contains(content, "Tags") {
+		t.Errorf("legacy valpkg output = %s, want Tags skipped", content)
+	}
+
+	if _, err := a.runPackages([]string{"./testdata/remote/..."}, filepath.Join("gen", fromName), typesVal{"Val"}, nil); err == nil || !strings.Contains(err.Error(), "a file name is expected") {
+		t.Errorf("runPackages() error = %v, want a file name expected for -o", err)
+	}
+}
@@ -1,17 +1,30 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"go/format"
+	"go/parser"
+	"go/token"
 	"go/types"
 	"io"
 	"log"
 	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"text/template"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"golang.org/x/tools/go/packages"
 )
@@ -19,10 +32,52 @@ import (
 var (
 	pointerReceiverF = flag.Bool("pointer-receiver", false, "the generated receiver type")
 	maxDepthF        = flag.Int("maxdepth", 0, "max depth of deep copying")
-
-	typesF  typesVal
-	skipsF  skipsVal
-	outputF outputVal
+	intoF            = flag.Bool("into", false, "generate a DeepCopyInto method, to which DeepCopy delegates")
+	reuseF           = flag.Bool("reuse", false, "with -into, reuse the backing arrays of the slices of out, when large enough, instead of making new ones")
+	testsF           = flag.Bool("tests", false, "also look for the type in the package's test files")
+	genShallowF      = flag.Bool("gen-shallow", false, "also generate a ShallowCopy method, for comparison")
+	genEqualF        = flag.Bool("gen-equal", false, "also generate a DeepEqual method, comparing the copied members element-wise")
+	genSliceF        = flag.Bool("gen-slice-helper", false, "also generate a DeepCopyTSlice function, deep copying a slice of the type")
+	allocatorF       = flag.Bool("allocator", false, "generate a DeepCopyWith method too, allocating the copy with the typed hooks of a generated TAllocator interface")
+	strictF          = flag.Bool("strict", false, "fail on warnings about the generated code, such as values shared with the copy")
+	mergeF           = flag.Bool("merge", false, "merge the generated methods into the existing output file, replacing previous versions")
+	nilMapAsEmptyF   = flag.Bool("nil-map-as-empty", false, "copy nil maps as empty, non-nil maps")
+	aliasingF        = flag.Bool("preserve-aliasing", false, "copy the pointers met more than once by a copy to a single pointer, preserving the aliasing of the source")
+	preserveCapF     = flag.Bool("preserve-cap", false, "make the copied slices of the capacity of the source ones, instead of their length")
+	nilSliceAsEmptyF = flag.Bool("nil-slice-as-empty", false, "copy nil slices as empty, non-nil slices")
+	allF             = flag.Bool("all", false, "generate for every exported struct, slice and map type of the package, instead of those of -type")
+	listF            = flag.Bool("list", false, "list the types of the package a DeepCopy method can be generated for, instead of generating")
+	licenseF         = flag.String("license", "", "a file with the license header of the output, as a template of the {{.Year}} and {{.Holder}} of the copyright")
+	copyrightYearF   = flag.Int("copyright-year", 0, "the copyright year of the license header. Defaults to the current year")
+	copyrightHolderF = flag.String("copyright-holder", "", "the copyright holder of the license header")
+	tagF             = flag.String("tag", "", `the struct tag key, whose "skip" value shallow copies a field, and "zero" value zeroes it`)
+	dropChanF        = flag.Bool("drop-chan", false, "leave channels nil in the copy, instead of making new ones")
+	funcsF           = flag.Bool("func", false, "generate DeepCopyT functions instead of methods")
+	outPackageF      = flag.String("out-package", "", "the name of the output package, if it isn't the type's package. Implies -func")
+	jsonF            = flag.Bool("json", false, `write a JSON object of the generated "code", its "imports" and the "warnings", instead of the code`)
+	fromF            = flag.String("from", "", `a file of the types to generate, one "path.Type [skip=selectors]" per line, instead of the package path and -type flags`)
+	printASTF        = flag.Bool("print-ast", false, "print the type structure of each type to stderr, before generating")
+	importMapF       = flag.String("import-map", "", `a file of "path alias" lines, pinning the names of the imported packages`)
+	runtimeOptsF     = flag.Bool("runtime-opts", false, "generate a DeepCopyOpts method, deep copying the -optional members only when set by its TCopyOptions argument, to which DeepCopy delegates with all of them set")
+	benchCompareF    = flag.String("benchmark-compare", "", "a test file to write a benchmark of the generated DeepCopy methods against a reflect based copy to")
+	verifyCompilesF  = flag.Bool("verify-compiles", false, "type check the package with the generated output, failing if it doesn't compile")
+	deepKeysF        = flag.Bool("deep-keys", false, "deep copy the keys of maps, instead of sharing them. Keys with pointers then no longer match those of the source")
+	explainF         = flag.Bool("explain", false, "annotate the generated statements with a comment explaining how the member is copied")
+	noFormatF        = flag.Bool("no-format", false, "write the generated code unformatted, for debugging. The output may not be gofmt-clean")
+	skipExprF        = flag.String("skip-expr", "", `an expression of the field/slice/map selectors to shallow copy in every type, such as 'field.endsWith("Cache")'`)
+	methodF          = flag.String("method", "DeepCopy", "the name of the generated method, such as Clone, which is also the one reused by the members")
+	ifaceMethodF     = flag.String("iface-method", "DeepCopy", "the name of the method deep copying the values of interfaces, such as DeepCopyObject")
+	ifaceDynamicF    = flag.Bool("iface-dynamic", false, "copy the values of interfaces without a DeepCopy method, nor implementations, with the DeepCopy method of their dynamic type, looked up with reflect, sharing them if it has none")
+
+	typesF      typesVal
+	skipsF      skipsVal
+	skipAllF    skipAllVal
+	optionalF   optionalVal
+	deepF       typesVal
+	skipTypesF  typesVal
+	ifaceImplsF = ifaceImplsVal{}
+	outputF     outputVal
+	fileModeF   = fileModeVal(0666)
 )
 
 type typesVal []string
@@ -70,12 +125,92 @@ func (s skips) Contains(sel string) bool {
 		return ok
 	}
 
+	for pattern := range s {
+		if strings.Contains(pattern, "*") && matchSelector(pattern, sel) {
+			return true
+		}
+	}
+
 	return false
 }
 
+// selectorEscaper turns a selector pattern into a path.Match pattern, its
+// segments into path elements, and its indexes into literals.
+var selectorEscaper = strings.NewReplacer(".", "/", "[", `\[`, "]", `\]`)
+
+// matchSelector reports whether the selector matches the pattern, whose *
+// wildcards each stand for a single segment, such as "Inner.*.Entries" or
+// "*.Cache", indexes included, as in "Items[i]".
+func matchSelector(pattern, sel string) bool {
+	ok, _ := path.Match(selectorEscaper.Replace(pattern), strings.ReplaceAll(sel, ".", "/"))
+
+	return ok
+}
+
+type skipAllVal []string
+
+func (f *skipAllVal) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *skipAllVal) Set(v string) error {
+	if _, err := path.Match(selectorEscaper.Replace(v), ""); err != nil {
+		if _, reErr := regexp.Compile(v); reErr != nil {
+			return fmt.Errorf("invalid pattern %q: %v", v, err)
+		}
+	}
+
+	*f = append(*f, v)
+
+	return nil
+}
+
+type ifaceImplsVal map[string][]string
+
+func (f ifaceImplsVal) String() string {
+	parts := make([]string, 0, len(f))
+	for iface, impls := range f {
+		parts = append(parts, iface+"="+strings.Join(impls, ","))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func (f ifaceImplsVal) Set(v string) error {
+	iface, impls, ok := strings.Cut(v, "=")
+	if !ok || iface == "" || impls == "" {
+		return fmt.Errorf("invalid interface implementations %q, expected Iface=Impl1,Impl2", v)
+	}
+
+	f[iface] = append(f[iface], strings.Split(impls, ",")...)
+
+	return nil
+}
+
+type optionalVal []string
+
+func (f *optionalVal) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *optionalVal) Set(v string) error {
+	for _, name := range strings.Split(v, ".") {
+		if !token.IsIdentifier(name) {
+			return fmt.Errorf("invalid optional selector %q, expected a field such as Cache or Field.Cache", v)
+		}
+	}
+
+	*f = append(*f, v)
+
+	return nil
+}
+
 type outputVal struct {
-	file *os.File
 	name string
+	// path is the output file, or empty when writing to STDOUT. The file is
+	// created with the mode, modified by the umask.
+	path string
+	mode os.FileMode
 }
 
 func (f *outputVal) String() string {
@@ -84,60 +219,203 @@ func (f *outputVal) String() string {
 
 func (f *outputVal) Set(v string) error {
 	if v == "-" || v == "" {
-		f.name = "stdout"
-
-		if f.file != nil {
-			_ = f.file.Close()
-		}
-		f.file = nil
+		f.name, f.path = "stdout", ""
 
 		return nil
 	}
 
-	file, err := os.OpenFile(v, os.O_RDWR|os.O_CREATE, 0666)
-	if err != nil {
-		return fmt.Errorf("opening file: %v", v)
+	f.name, f.path = v, v
+
+	return nil
+}
+
+// Existing returns the current content of the output file, or nil when
+// writing to STDOUT, or the file doesn't exist yet.
+func (f *outputVal) Existing() ([]byte, error) {
+	if f.path == "" {
+		return nil, nil
 	}
 
-	f.name = v
-	f.file = file
+	b, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
 
-	return nil
+	return b, err
 }
 
 func (f *outputVal) Open() (io.WriteCloser, error) {
-	if f.file == nil {
-		f.file = os.Stdout
-	} else {
-		err := f.file.Truncate(0)
-		if err != nil {
-			return nil, err
-		}
+	if f.path == "" {
+		return os.Stdout, nil
+	}
+
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.mode)
+	if err != nil {
+		return nil, fmt.Errorf("opening file: %v", err)
+	}
+
+	return file, nil
+}
+
+type fileModeVal os.FileMode
+
+func (f *fileModeVal) String() string {
+	return fmt.Sprintf("%#o", uint32(*f))
+}
+
+func (f *fileModeVal) Set(v string) error {
+	mode, err := strconv.ParseUint(v, 8, 32)
+	if err != nil || mode > 0777 {
+		return fmt.Errorf("invalid file mode %q, expected permission bits such as 0644", v)
 	}
 
-	return f.file, nil
+	*f = fileModeVal(mode)
+
+	return nil
 }
 
 func init() {
 	flag.Var(&typesF, "type", "the concrete type. Multiple flags can be specified")
 	flag.Var(&skipsF, "skip", "comma-separated field/slice/map selectors to shallow copy. Multiple flags can be specified")
+	flag.Var(&deepF, "deep", "field/slice/map selector to deep copy in every type, even if skipped by a -skip-all pattern or tag. Interfaces must then be copied by their DeepCopy method or -iface-impls. Multiple flags can be specified")
+	flag.Var(&skipTypesF, "skip-type", "type, qualified by its import path such as time.Time, copied by assignment wherever it appears, without walking it. Multiple flags can be specified")
+	flag.Var(&skipAllF, "skip-all", "glob, or regular expression, pattern of field/slice/map selectors to shallow copy in every type. Multiple flags can be specified")
+	flag.Var(&optionalF, "optional", "field selector, such as Cache, deep copied by the DeepCopyOpts method of -runtime-opts only when its CopyCache option is set. Multiple flags can be specified")
+	flag.Var(ifaceImplsF, "iface-impls", "comma-separated implementations of an interface to copy with a type switch, as Iface=Impl1,*Impl2. Multiple flags can be specified")
 	flag.Var(&outputF, "o", "the output file to write to. Defaults to STDOUT")
+	flag.Var(&fileModeF, "file-mode", "the permissions of the created output files, modified by the umask")
 }
 
 func main() {
 	flag.Parse()
 
-	if len(typesF) == 0 || typesF[0] == "" {
+	if *fromF != "" {
+		if flag.NArg() != 0 || len(typesF) != 0 || len(skipsF) != 0 || outputF.name != "" || *allF || *listF || *jsonF || *benchCompareF != "" {
+			log.Fatalln("-from can't be combined with a package path, -type, -skip, -o, -all, -list, -json or -benchmark-compare flags")
+		}
+	} else if flag.NArg() == 0 {
+		log.Fatalln("No package path given")
+	} else if isPackages(flag.Args()) && (*listF || *jsonF || *benchCompareF != "") {
+		log.Fatalln("several packages can't be combined with -list, -json or -benchmark-compare flags")
+	}
+
+	if *listF {
+		a := &app{tests: *testsF}
+		names, err := a.listTypes(flag.Args()[0])
+		if err != nil {
+			log.Fatalln("Error listing types:", err)
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return
+	}
+
+	if *fromF == "" && !*allF && (len(typesF) == 0 || typesF[0] == "") {
 		log.Fatalln("no type given")
 	}
 
-	if flag.NArg() != 1 {
-		log.Fatalln("No package path given")
+	var license string
+	if *licenseF != "" {
+		text, err := os.ReadFile(*licenseF)
+		if err != nil {
+			log.Fatalln("Error reading license file:", err)
+		}
+
+		year := *copyrightYearF
+		if year == 0 {
+			year = time.Now().Year()
+		}
+
+		license, err = licenseHeader(string(text), year, *copyrightHolderF)
+		if err != nil {
+			log.Fatalln("Error generating license header:", err)
+		}
+	} else if *copyrightYearF != 0 || *copyrightHolderF != "" {
+		log.Fatalln("-copyright-year and -copyright-holder require -license")
+	}
+
+	var importAliases map[string]string
+	if *importMapF != "" {
+		f, err := os.Open(*importMapF)
+		if err != nil {
+			log.Fatalln("Error reading import map:", err)
+		}
+
+		importAliases, err = parseImportMap(f)
+		f.Close()
+		if err != nil {
+			log.Fatalln("Error parsing import map:", err)
+		}
+	}
+
+	var expr *skipExpr
+	if *skipExprF != "" {
+		var err error
+		if expr, err = parseSkipExpr(*skipExprF); err != nil {
+			log.Fatalln("Error parsing skip expression:", err)
+		}
 	}
 
 	a := &app{
-		isPtrRecv: *pointerReceiverF,
-		maxDepth:  *maxDepthF,
+		isPtrRecv:       *pointerReceiverF,
+		maxDepth:        *maxDepthF,
+		into:            *intoF,
+		reuse:           *reuseF,
+		skipAll:         skipAllF,
+		skipExpr:        expr,
+		deep:            deepF,
+		skipTypes:       skipTypesF,
+		tests:           *testsF,
+		genShallow:      *genShallowF,
+		genEqual:        *genEqualF,
+		genSlice:        *genSliceF,
+		runtimeOpts:     *runtimeOptsF,
+		optional:        optionalF,
+		strict:          *strictF,
+		allocator:       *allocatorF,
+		nilMapAsEmpty:   *nilMapAsEmptyF,
+		nilSliceAsEmpty: *nilSliceAsEmptyF,
+		preserveCap:     *preserveCapF,
+		aliasing:        *aliasingF,
+		dropChan:        *dropChanF,
+		deepKeys:        *deepKeysF,
+		tag:             *tagF,
+		license:         license,
+		funcs:           *funcsF || *outPackageF != "",
+		outPackage:      *outPackageF,
+		ifaceImpls:      ifaceImplsF,
+		method:          *methodF,
+		ifaceMethod:     *ifaceMethodF,
+		ifaceDynamic:    *ifaceDynamicF,
+		importAliases:   importAliases,
+		noFormat:        *noFormatF,
+		explain:         *explainF,
+		all:             *allF,
+	}
+	if *printASTF {
+		a.typeTree = os.Stderr
+	}
+	a.output = outputF.path
+	outputF.mode = os.FileMode(fileModeF)
+
+	if *fromF != "" {
+		if err := generateFrom(a, *fromF, os.FileMode(fileModeF), *mergeF, *verifyCompilesF); err != nil {
+			log.Fatalln("Error generating deep copy methods:", err)
+		}
+		return
+	}
+
+	if isPackages(flag.Args()) {
+		name := fromName
+		if outputF.path != "" {
+			name = outputF.path
+		}
+
+		if err := generatePackages(a, flag.Args(), name, typesF, skipsF, os.FileMode(fileModeF), *mergeF, *verifyCompilesF); err != nil {
+			log.Fatalln("Error generating deep copy methods:", err)
+		}
+		return
 	}
 
 	b, err := a.run(flag.Args()[0], typesF, skipsF)
@@ -145,6 +423,41 @@ func main() {
 		log.Fatalln("Error generating deep copy method:", err)
 	}
 
+	if *mergeF {
+		existing, err := outputF.Existing()
+		if err != nil {
+			log.Fatalln("Error reading output file:", err)
+		}
+
+		b, err = mergeFile(existing, b)
+		if err != nil {
+			log.Fatalln("Error merging into output file:", err)
+		}
+	}
+
+	if *verifyCompilesF {
+		if err := verifyCompiles(flag.Args()[0], outputF.path, b, *testsF); err != nil {
+			log.Fatalln("Error verifying generated code:", err)
+		}
+	}
+
+	if *benchCompareF != "" {
+		bench, err := a.benchmarkFile(b)
+		if err != nil {
+			log.Fatalln("Error generating benchmark:", err)
+		}
+		if err := os.WriteFile(*benchCompareF, bench, os.FileMode(fileModeF)); err != nil {
+			log.Fatalln("Error writing benchmark file:", err)
+		}
+	}
+
+	if *jsonF {
+		b, err = jsonOutput(b, a.warnings)
+		if err != nil {
+			log.Fatalln("Error encoding JSON output:", err)
+		}
+	}
+
 	output, err := outputF.Open()
 	if err != nil {
 		log.Fatalln("Error initializing output file:", err)
@@ -156,46 +469,350 @@ func main() {
 }
 
 type app struct {
-	isPtrRecv bool
-	maxDepth  int
+	isPtrRecv  bool
+	maxDepth   int
+	into       bool
+	reuse      bool
+	skipAll    []string
+	skipAllRes []*regexp.Regexp
+	skipExpr   *skipExpr
+	deep       []string
+	skipTypes  []string
+	tests      bool
+	genShallow bool
+	genEqual   bool
+	genSlice   bool
+	strict     bool
+	allocator  bool
+	output     string
+
+	// runtimeOpts generates DeepCopyOpts methods, deep copying the optional
+	// field selectors only when their option is set.
+	runtimeOpts bool
+	optional    []string
+
+	nilMapAsEmpty   bool
+	nilSliceAsEmpty bool
+	preserveCap     bool
+	dropChan        bool
+	deepKeys        bool
+	tag             string
+	license         string
+
+	// all generates for every exported type of the package, as listed by
+	// allTypes, instead of the given ones.
+	all bool
+
+	// aliasing copies the pointers met again by a copy to the pointer they
+	// were first copied to, recorded in its visited map, once aliased is set
+	// by walkType.
+	aliasing bool
+	aliased  bool
+
+	// funcs generates functions instead of methods, as required for copying
+	// types of another package than outPackage.
+	funcs      bool
+	outPackage string
+
+	// ifaceImpls lists the implementations of interfaces without a DeepCopy
+	// method, by name, which are resolved to impls.
+	ifaceImpls map[string][]string
+	impls      map[*types.TypeName][]types.Type
+
+	// method is the name of the generated method, and of those of the
+	// members reused, DeepCopy if empty.
+	method string
+
+	// ifaceMethod is the name of the method deep copying the values of
+	// interfaces, DeepCopy if empty.
+	ifaceMethod string
+
+	// ifaceDynamic copies the values of the other non-empty interfaces with
+	// the method of their dynamic type, if any, found at runtime.
+	ifaceDynamic bool
+
+	// importAliases pins the names of the imported packages, by their path,
+	// and pkgNames records their actual names.
+	importAliases map[string]string
+	pkgNames      map[string]string
+
+	// typeTree is written the type structure of each type, if set.
+	typeTree io.Writer
+
+	// noFormat writes the generated code as is, without formatting it.
+	noFormat bool
+
+	// explain annotates the generated statements with the reason they were
+	// generated for.
+	explain bool
+
+	// failures are the errors found while walking the types, such as
+	// interfaces to deep copy without a way to.
+	failures []string
+
+	// quiet disables logging the warnings, which are still recorded.
+	quiet bool
+
+	// dir is the directory of the package generated for, once loaded, and
+	// localPath its import path, unless generating in another package. fset
+	// positions the declarations of its files, and pkg holds its types.
+	dir       string
+	localPath string
+	fset      *token.FileSet
+	pkg       *types.Package
+
+	current  object
+	warnings []string
+
+	// options are the fields of the options of the DeepCopyOpts method
+	// being generated, by the optional members found.
+	options []string
+
+	// expanding are the named types being inlined by walkType, and implied
+	// the recursive ones among them, generated as if given with -type.
+	expanding []*types.Named
+	implied   []object
+
+	// reused are the sinks of the slices of out, whose backing arrays are
+	// reused by the DeepCopyInto method being generated.
+	reused []string
+
+	// allocations are those of the DeepCopyWith methods generated, declaring
+	// their allocator interfaces, and allocating that of the method being
+	// generated.
+	allocations []*allocation
+	allocating  *allocation
+
+	// valueSinks are the selectors of the map keys and values, by the names
+	// of the variables they are copied to, as reported by selector.
+	valueSinks map[string]string
+
+	// mapValueSels are the selectors of the members of map values of the
+	// type being generated, in the form of the selectors of its own members,
+	// as matched by -skip before they were selected through the map.
+	mapValueSels map[string]string
+}
+
+// allocation is what the DeepCopyWith method of a type allocates: the hooks of
+// its allocator, by name, and the generated types it copies with their own
+// DeepCopyWith method.
+type allocation struct {
+	obj     object
+	hooks   map[string]string
+	callees []allocCallee
+}
+
+// allocCallee is a generated type copied with its DeepCopyWith method, as
+// instantiated by the caller.
+type allocCallee struct {
+	obj  object
+	kind string
+}
+
+// locateError is the error of a type that can't be located in the package.
+type locateError struct {
+	kind, pkg string
+	err       error
+}
+
+func (e *locateError) Error() string {
+	return fmt.Sprintf("locating type %q in %q: %v", e.kind, e.pkg, e.err)
+}
+
+// warnf logs a warning about the generated code, and records it.
+func (a *app) warnf(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if !a.quiet {
+		log.Println("WARNING:", msg)
+	}
+	a.warnings = append(a.warnings, msg)
+}
+
+// explained returns a writer annotating the first line written to w with a
+// trailing comment of the reason, when explaining the generated code.
+func (a *app) explained(w io.Writer, format string, args ...interface{}) io.Writer {
+	if !a.explain {
+		return w
+	}
+
+	return &explainWriter{w: w, note: " // " + fmt.Sprintf(format, args...)}
+}
+
+// explainedType returns the name of the type in the explanations, qualified by
+// the name of its package unless local, without importing it.
+func (a *app) explainedType(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if a.isLocal(p) {
+			return ""
+		}
+		return p.Name()
+	})
+}
+
+// explainWriter appends the note to the first line written to w.
+type explainWriter struct {
+	w    io.Writer
+	note string
+	done bool
+}
+
+func (e *explainWriter) Write(p []byte) (int, error) {
+	i := bytes.IndexByte(p, '\n')
+	if e.done || i < 0 {
+		return e.w.Write(p)
+	}
+
+	e.done = true
+	if _, err := fmt.Fprintf(e.w, "%s%s%s", p[:i], e.note, p[i:]); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
 }
 
 func (a *app) run(path string, types typesVal, skips skipsVal) ([]byte, error) {
-	packages, err := load(path)
+	implPaths := a.implPackages()
+	all, err := load(path, a.tests, implPaths...)
 	if err != nil {
 		return nil, fmt.Errorf("loading package: %v", err)
 	}
+
+	// The packages of the implementations are only loaded for their types.
+	var packages []*packages.Package
+	for _, p := range all {
+		if !contains(implPaths, p.PkgPath) {
+			packages = append(packages, p)
+		}
+	}
 	if len(packages) == 0 {
 		return nil, errors.New("no package found")
 	}
 
+	p := packages[0]
+	if a.tests && len(types) > 0 {
+		p = testVariant(packages, types[0])
+	}
+	if len(p.GoFiles) > 0 {
+		a.dir = filepath.Dir(p.GoFiles[0])
+	}
+	a.fset = p.Fset
+	a.pkg = p.Types
+	if a.outPackage == "" {
+		a.localPath = p.PkgPath
+	}
+
+	if a.allocator && (a.into || a.funcs) {
+		return nil, errors.New("an allocator can't be used with DeepCopyInto methods, or functions")
+	}
+	if a.allocator && a.genSlice {
+		return nil, errors.New("an allocator can't be used with slice helpers")
+	}
+	if a.reuse && !a.into {
+		return nil, errors.New("reusing the slices of the destination requires DeepCopyInto methods")
+	}
+	if a.reuse && a.aliasing {
+		return nil, errors.New("the aliasing of the source can't be preserved when reusing the slices of the destination")
+	}
+	if a.method != "" && !token.IsIdentifier(a.method) {
+		return nil, fmt.Errorf("invalid method name %q", a.method)
+	}
+	for _, name := range a.skipTypes {
+		if i := strings.LastIndex(name, "."); i <= 0 || !token.IsIdentifier(name[i+1:]) {
+			return nil, fmt.Errorf("invalid skipped type %q, which must be qualified by its import path", name)
+		}
+	}
+	if a.runtimeOpts != (len(a.optional) > 0) {
+		return nil, errors.New("runtime options require both -runtime-opts and the -optional selectors they gate")
+	}
+	if a.runtimeOpts && (a.into || a.allocator) {
+		return nil, errors.New("runtime options can't be used with DeepCopyInto methods, or an allocator")
+	}
+	if a.all {
+		if len(types) > 0 {
+			return nil, errors.New("-all generates for every exported type, it can't be combined with -type")
+		}
+		if len(skips) > 0 {
+			return nil, errors.New("-skip selectors apply to the types given with -type, they can't be combined with -all")
+		}
+
+		if types = a.matchTypes(p, token.IsExported); len(types) == 0 {
+			return nil, &locateError{kind: "*", pkg: p.Name, err: errors.New("no exported type to generate for")}
+		}
+	}
+	if types, skips, err = a.expandTypes(p, types, skips); err != nil {
+		return nil, err
+	}
+
+	if err := a.resolveImpls(p, all); err != nil {
+		return nil, err
+	}
+
 	imports := map[string]string{}
 	fns := [][]byte{}
 
 	objs := make([]object, len(types))
 	for i, kind := range types {
-		obj, err := locateType(packages[0].Name, kind, packages[0])
+		obj, err := locateType(p.Name, kind, p)
 		if err != nil {
-			return nil, fmt.Errorf("locating type %q in %q: %v", kind, packages[0].Name, err)
+			return nil, &locateError{kind: kind, pkg: p.Name, err: err}
+		}
+		if a.outPackage != "" && !obj.Obj().Exported() {
+			return nil, fmt.Errorf("type %q is not exported, it can't be copied in package %q", kind, a.outPackage)
 		}
 		objs[i] = obj
 	}
 
-	for i, obj := range objs {
+	for i, s := range skips {
+		for _, sel := range a.deep {
+			if i < len(types) && s.Contains(sel) {
+				return nil, fmt.Errorf("selector %q of type %q is both skipped and deep copied", sel, types[i])
+			}
+		}
+	}
+
+	if a.typeTree != nil {
+		for _, obj := range objs {
+			writeTypeTree(a.typeTree, "", obj, p.Types, 0, nil)
+		}
+	}
+
+	// The types implied by those generated are appended as they are found.
+	for i := 0; i < len(objs); i++ {
 		var s map[string]struct{}
 		if i < len(skips) {
 			s = skips[i]
 		}
 
-		fn, err := a.generateFunc(packages[0], obj, imports, s, objs)
+		fn, err := a.generateFunc(p, objs[i], imports, s, objs)
 		if err != nil {
 			return nil, fmt.Errorf("generating method: %v", err)
 		}
 
 		fns = append(fns, fn)
+
+		for _, obj := range a.implied {
+			if generatingType(obj, objs) == nil {
+				objs = append(objs, obj)
+			}
+		}
+		a.implied = nil
+	}
+
+	if len(a.failures) > 0 {
+		return nil, errors.New(strings.Join(a.failures, "\n"))
+	}
+
+	if a.strict && len(a.warnings) > 0 {
+		return nil, fmt.Errorf("%d warnings in strict mode, first: %s", len(a.warnings), a.warnings[0])
+	}
+
+	// The allocator interfaces include the hooks of the types copied with
+	// their DeepCopyWith method, which are only known once all generated.
+	for _, alloc := range a.allocations {
+		fns = append(fns, a.allocatorInterface(alloc, a.localPackage(p), imports))
 	}
 
-	b, err := generateFile(packages[0], imports, fns)
+	b, err := generateFile(a.localPackage(p), a.license, imports, a.pkgNames, fns, a.noFormat)
 	if err != nil {
 		return nil, fmt.Errorf("generating file content: %v", err)
 	}
@@ -203,58 +820,800 @@ func (a *app) run(path string, types typesVal, skips skipsVal) ([]byte, error) {
 	return b, nil
 }
 
-func load(patterns string) ([]*packages.Package, error) {
-	return packages.Load(&packages.Config{
-		Mode: packages.NeedName | packages.NeedFiles | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedDeps | packages.NeedImports,
-	}, patterns)
+// writeTypeTree writes the structure of the type, one member per line, labeled
+// and indented by depth: the fields of structs, the elements of slices, arrays,
+// pointers and channels, and the keys and values of maps. Types are qualified
+// relative to the package, and those already on the path aren't walked again.
+func writeTypeTree(w io.Writer, label string, t types.Type, pkg *types.Package, depth int, path []types.Type) {
+	notes := []string{kindOf(t)}
+	if ms := types.NewMethodSet(types.NewPointer(t)); ms.Lookup(nil, "DeepCopy") != nil {
+		notes = append(notes, "DeepCopy")
+	}
+
+	// Value types and locks aren't walked by the generator either.
+	leaf := true
+	switch {
+	case isValueType(t):
+		notes = append(notes, "value type")
+	case isLock(t):
+		notes = append(notes, "lock")
+	default:
+		leaf = false
+		for _, seen := range path {
+			if types.Identical(seen, t) {
+				leaf = true
+				notes = append(notes, "recursive")
+			}
+		}
+	}
+
+	if label != "" {
+		label += " "
+	}
+	fmt.Fprintf(w, "%s%s%s (%s)\n", strings.Repeat("  ", depth), label, types.TypeString(t, types.RelativeTo(pkg)), strings.Join(notes, ", "))
+	if leaf {
+		return
+	}
+
+	path = append(path, t)
+	depth++
+	switch v := t.Underlying().(type) {
+	case *types.Struct:
+		for i := 0; i < v.NumFields(); i++ {
+			writeTypeTree(w, v.Field(i).Name(), v.Field(i).Type(), pkg, depth, path)
+		}
+	case *types.Slice:
+		writeTypeTree(w, "[i]", v.Elem(), pkg, depth, path)
+	case *types.Array:
+		writeTypeTree(w, "[i]", v.Elem(), pkg, depth, path)
+	case *types.Pointer:
+		writeTypeTree(w, "*", v.Elem(), pkg, depth, path)
+	case *types.Chan:
+		writeTypeTree(w, "<-", v.Elem(), pkg, depth, path)
+	case *types.Map:
+		writeTypeTree(w, "key", v.Key(), pkg, depth, path)
+		writeTypeTree(w, "[k]", v.Elem(), pkg, depth, path)
+	}
 }
 
-func (a *app) generateFunc(p *packages.Package, obj object, imports map[string]string, skips map[string]struct{}, generating []object) ([]byte, error) {
-	var buf bytes.Buffer
+// kindOf returns the kind of the type, as printed by writeTypeTree.
+func kindOf(t types.Type) string {
+	if _, ok := t.(*types.TypeParam); ok {
+		return "type parameter"
+	}
 
-	var ptr string
-	if a.isPtrRecv {
-		ptr = "*"
+	switch t.Underlying().(type) {
+	case *types.Struct:
+		return "struct"
+	case *types.Slice:
+		return "slice"
+	case *types.Array:
+		return "array"
+	case *types.Pointer:
+		return "pointer"
+	case *types.Chan:
+		return "chan"
+	case *types.Map:
+		return "map"
+	case *types.Interface:
+		return "interface"
+	case *types.Signature:
+		return "func"
+	default:
+		return "basic"
 	}
-	kind := obj.Obj().Name()
+}
 
-	source := "o"
-	fmt.Fprintf(&buf, `// DeepCopy generates a deep copy of %s%s
-func (o %s%s) DeepCopy() %s%s {
-	var cp %s = %s%s
-`, ptr, kind, ptr, kind, ptr, kind, kind, ptr, source)
+// matchTypes returns the names of the types of the package of a struct, slice
+// or map kind matched, as generated with -all or a -type pattern, whether
+// their members are copied by assignment alone or not. Those already declaring
+// the method, outside of the output file, are left out.
+func (a *app) matchTypes(p *packages.Package, match func(name string) bool) typesVal {
+	var names typesVal
+	scope := p.Types.Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || tn.IsAlias() || !match(name) {
+			continue
+		}
 
-	a.walkType(source, "cp", p.Name, obj, &buf, imports, skips, generating, 0)
+		obj, ok := tn.Type().(object)
+		if !ok {
+			continue
+		}
 
-	if a.isPtrRecv {
-		buf.WriteString("return &cp\n}")
-	} else {
-		buf.WriteString("return cp\n}")
+		switch obj.Underlying().(type) {
+		case *types.Struct, *types.Slice, *types.Map:
+		default:
+			continue
+		}
+
+		if !a.funcs && a.declaresMethod(p, obj, a.copyMethod()) {
+			continue
+		}
+
+		names = append(names, name)
 	}
 
-	return buf.Bytes(), nil
+	return names
 }
 
-func generateFile(p *packages.Package, imports map[string]string, fn [][]byte) ([]byte, error) {
-	var file bytes.Buffer
+// expandTypes replaces the -type patterns, such as "*Spec" or "Config.*", by
+// the types they match, each with the skips of the pattern. Types given more
+// than once are generated once.
+func (a *app) expandTypes(p *packages.Package, kinds typesVal, skips skipsVal) (typesVal, skipsVal, error) {
+	var expanded typesVal
+	var expandedSkips skipsVal
+	add := func(kind string, i int) {
+		expanded = append(expanded, kind)
+		if i < len(skips) {
+			expandedSkips = append(expandedSkips, skips[i])
+		} else {
+			expandedSkips = append(expandedSkips, nil)
+		}
+	}
 
-	fmt.Fprintf(&file, "// generated by %s; DO NOT EDIT.\n\npackage %s\n\n", strings.Join(os.Args, " "), p.Name)
+	for i, kind := range kinds {
+		match := typePattern(kind)
+		if match == nil {
+			add(kind, i)
+			continue
+		}
 
-	if len(imports) > 0 {
-		file.WriteString("import (\n")
-		for name, path := range imports {
-			if strings.HasSuffix(path, name) {
-				fmt.Fprintf(&file, "%q\n", path)
-			} else {
-				fmt.Fprintf(&file, "%s %q\n", name, path)
+		names := a.matchTypes(p, match)
+		if len(names) == 0 {
+			return nil, nil, &locateError{kind: kind, pkg: p.Name, err: fmt.Errorf("no type without a %s method matches it", a.copyMethod())}
+		}
+		for _, name := range names {
+			if !contains(kinds, name) && !contains(expanded, name) {
+				add(name, i)
 			}
 		}
-		file.WriteString(")\n")
 	}
 
-	for _, fn := range fn {
-		file.Write(fn)
-		file.WriteString("\n\n")
+	if len(skips) == 0 {
+		expandedSkips = nil
+	}
+
+	return expanded, expandedSkips, nil
+}
+
+// typePattern returns the matcher of the names of the types of a -type
+// pattern, matched either as a glob, such as "*Spec", or as a regular
+// expression, such as "Config.*", or nil if it names a single type.
+func typePattern(kind string) func(name string) bool {
+	if !strings.ContainsAny(kind, "*?") {
+		return nil
+	}
+
+	re, err := regexp.Compile("^(?:" + kind + ")$")
+
+	return func(name string) bool {
+		if ok, _ := path.Match(kind, name); ok {
+			return true
+		}
+
+		return err == nil && re.MatchString(name)
+	}
+}
+
+// listTypes returns the names of the package's types a DeepCopy method can be
+// generated for, those of a struct, slice or map kind with members that aren't
+// copied by assignment alone.
+func (a *app) listTypes(path string) ([]string, error) {
+	packages, err := load(path, a.tests)
+	if err != nil {
+		return nil, fmt.Errorf("loading package: %v", err)
+	}
+	if len(packages) == 0 {
+		return nil, errors.New("no package found")
+	}
+
+	p := packages[0]
+	if a.tests {
+		for _, tp := range packages {
+			if strings.HasSuffix(tp.ID, ".test]") && tp.PkgPath == p.PkgPath {
+				p = tp
+				break
+			}
+		}
+	}
+
+	// Only the generated code matters, not the warnings about it.
+	probe := *a
+	probe.quiet = true
+	probe.localPath = p.PkgPath
+
+	var names []string
+	scope := p.Types.Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || tn.IsAlias() {
+			continue
+		}
+
+		obj, ok := tn.Type().(object)
+		if !ok {
+			continue
+		}
+
+		switch obj.Underlying().(type) {
+		case *types.Struct, *types.Slice, *types.Map:
+		default:
+			continue
+		}
+
+		var buf bytes.Buffer
+		probe.current = obj
+		probe.walkType("o", "cp", p.Name, obj, &buf, map[string]string{}, nil, []object{obj}, 0)
+		if buf.Len() > 0 {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
+// implPackages returns the import paths of the packages of the interfaces and
+// implementations qualified with one.
+func (a *app) implPackages() []string {
+	var paths []string
+	seen := map[string]struct{}{}
+	for iface, impls := range a.ifaceImpls {
+		for _, name := range append([]string{iface}, impls...) {
+			path, _ := splitQualified(strings.TrimPrefix(name, "*"))
+			if _, ok := seen[path]; ok || path == "" {
+				continue
+			}
+			seen[path] = struct{}{}
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// resolveImpls resolves the interfaces and implementations given by name to
+// the types of the package, or those of the loaded packages when qualified by
+// their import path.
+func (a *app) resolveImpls(p *packages.Package, all []*packages.Package) error {
+	a.impls = make(map[*types.TypeName][]types.Type, len(a.ifaceImpls))
+	for name, implNames := range a.ifaceImpls {
+		obj, err := lookupType(name, p, all)
+		if err != nil {
+			return fmt.Errorf("locating interface %q in %q: %v", name, p.Name, err)
+		}
+		iface, ok := obj.Underlying().(*types.Interface)
+		if !ok {
+			return fmt.Errorf("type %q is not an interface", name)
+		}
+
+		for _, implName := range implNames {
+			ptr := strings.HasPrefix(implName, "*")
+			impl, err := lookupType(strings.TrimPrefix(implName, "*"), p, all)
+			if err != nil {
+				return fmt.Errorf("locating implementation %q of %q in %q: %v", implName, name, p.Name, err)
+			}
+
+			var t types.Type = impl
+			if ptr {
+				t = types.NewPointer(impl)
+			}
+			if !types.Implements(t, iface) {
+				return fmt.Errorf("type %q doesn't implement %q", implName, name)
+			}
+
+			a.impls[obj.Obj()] = append(a.impls[obj.Obj()], t)
+		}
+	}
+
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitQualified splits a type name qualified by an import path, such as
+// "example.com/pkg.Type", into the path and the name of the type.
+func splitQualified(name string) (path, typeName string) {
+	i := strings.LastIndex(name, ".")
+	if i < 0 {
+		return "", name
+	}
+
+	return name[:i], name[i+1:]
+}
+
+// lookupType returns the type of the package with the given name, or that of
+// the loaded packages if qualified by its import path.
+func lookupType(name string, p *packages.Package, all []*packages.Package) (object, error) {
+	path, typeName := splitQualified(name)
+	if path == "" {
+		return locateType(p.Name, name, p)
+	}
+
+	var pkg *types.Package
+	packages.Visit(all, func(lp *packages.Package) bool {
+		if lp.PkgPath == path && lp.Types != nil {
+			pkg = lp.Types
+		}
+		return pkg == nil
+	}, nil)
+	if pkg == nil {
+		return nil, fmt.Errorf("package %q not loaded", path)
+	}
+
+	tn, ok := pkg.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return nil, errors.New("type not found")
+	}
+	obj, ok := tn.Type().(object)
+	if !ok {
+		return nil, errors.New("type not found")
+	}
+
+	return obj, nil
+}
+
+func load(patterns string, tests bool, extra ...string) ([]*packages.Package, error) {
+	cfg, patterns := loadConfig(patterns, tests)
+
+	return packages.Load(cfg, append([]string{patterns}, extra...)...)
+}
+
+// loadConfig returns the configuration loading the packages of the patterns,
+// and the patterns relative to its directory.
+func loadConfig(patterns string, tests bool) (*packages.Config, string) {
+	// The types are checked from all the files of each package, so that the
+	// method sets are complete, wherever the methods are declared.
+	cfg := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedDeps | packages.NeedImports,
+		Tests: tests,
+	}
+
+	// Load a package directory from within it, so that its own module, and
+	// vendor directory, are used even if it isn't the current one.
+	if fi, err := os.Stat(patterns); err == nil && fi.IsDir() {
+		cfg.Dir, patterns = patterns, "."
+	}
+
+	return cfg, patterns
+}
+
+// testVariant returns the first package compiled together with its test files,
+// either the package itself or its external test package, that defines the
+// type. Falls back to the first package if none does.
+func testVariant(packages []*packages.Package, kind string) *packages.Package {
+	for _, p := range packages {
+		if !strings.HasSuffix(p.ID, ".test]") {
+			continue
+		}
+
+		if _, err := locateType(p.Name, kind, p); err == nil {
+			return p
+		}
+	}
+
+	return packages[0]
+}
+
+func (a *app) generateFunc(p *packages.Package, obj object, imports map[string]string, skips map[string]struct{}, generating []object) ([]byte, error) {
+	var buf bytes.Buffer
+
+	x := a.localPackage(p)
+	kind := typeName(obj)
+	if a.funcs {
+		kind = a.qualifiedTypeName(obj, x, imports)
+	}
+	a.current = obj
+	a.mapValueSels = nil
+
+	// Copying a value containing a lock would copy the lock too, so such
+	// values are passed around by their pointer, and copied field by field.
+	locks := containsLock(obj)
+	ptrRecv := a.ptrRecv(obj)
+	if locks && !a.isPtrRecv {
+		a.warnf("%s contains a lock, generating a pointer receiver", kind)
+	}
+
+	var ptr string
+	if ptrRecv {
+		ptr = "*"
+	}
+
+	if a.into {
+		source, sink := "o", "out"
+		if _, ok := obj.Underlying().(*types.Struct); !ok {
+			source, sink = deref(source), deref(sink)
+		}
+
+		// The slices of out to reuse are only known once walked, and have to
+		// be kept before out is overwritten.
+		var b bytes.Buffer
+		a.reused = nil
+		a.walkMethod(source, sink, x, obj, &b, imports, skips, generating, 0)
+
+		fmt.Fprintf(&buf, "// %s generates a deep copy of *%s into out\n", a.funcName(obj, "DeepCopyInto"), kind)
+		if len(a.reused) > 0 {
+			buf.WriteString(`//
+// The slices of out are reused, with the values their elements point to:
+// but for the slices of o themselves, which are never reused, out must not
+// share them with anything else.
+`)
+		}
+		fmt.Fprintf(&buf, "%s {\n", a.funcDecl(obj, x, imports, "DeepCopyInto", "o *"+kind, "out *"+kind, ""))
+
+		for _, reused := range a.reused {
+			fmt.Fprintf(&buf, "%s := %s\n", prevIdent(reused), reused)
+		}
+
+		if locks {
+			a.assignFields(&buf, "o", "out", obj)
+		} else {
+			buf.WriteString("*out = *o\n")
+		}
+
+		b.WriteTo(&buf)
+
+		into := "o.DeepCopyInto(&cp)"
+		if a.funcs {
+			recv := "o"
+			if !ptrRecv {
+				recv = "&o"
+			}
+			into = fmt.Sprintf("%s(%s, &cp)", a.funcName(obj, "DeepCopyInto"), recv)
+		}
+
+		fmt.Fprintf(&buf, `}
+
+// %s generates a deep copy of %s%s
+%s {
+	var cp %s
+	%s
+`, a.funcName(obj, a.copyMethod()), ptr, kind, a.funcDecl(obj, x, imports, a.copyMethod(), "o "+ptr+kind, "", ptr+kind), kind, into)
+	} else {
+		// Whether the copy takes options is only known once walked.
+		var b bytes.Buffer
+		source := "o"
+		if locks {
+			fmt.Fprintf(&b, "var cp %s\n", kind)
+			a.assignFields(&b, source, "cp", obj)
+		} else {
+			fmt.Fprintf(&b, "var cp %s = %s%s\n", kind, ptr, source)
+		}
+
+		if _, ok := obj.Underlying().(*types.Struct); !ok && ptrRecv {
+			source = deref(source)
+		}
+
+		a.options = nil
+		a.walkMethod(source, "cp", x, obj, &b, imports, skips, generating, 0)
+
+		if len(a.options) > 0 {
+			opts := obj.Obj().Name() + "CopyOptions"
+			fmt.Fprintf(&buf, `// %s selects the optional members deeply copied by %s,
+// which are otherwise shared with the copy.
+type %s struct {
+`, opts, a.funcName(obj, "DeepCopyOpts"), opts)
+			for _, opt := range a.options {
+				fmt.Fprintf(&buf, "%s bool\n", opt)
+			}
+			fmt.Fprintf(&buf, `}
+
+// %s generates a deep copy of %s%s, with the optional members selected by opts
+%s {
+`, a.funcName(obj, "DeepCopyOpts"), ptr, kind, a.funcDecl(obj, x, imports, "DeepCopyOpts", "o "+ptr+kind, "opts "+opts, ptr+kind))
+		} else {
+			fmt.Fprintf(&buf, `// %s generates a deep copy of %s%s
+%s {
+`, a.funcName(obj, a.copyMethod()), ptr, kind, a.funcDecl(obj, x, imports, a.copyMethod(), "o "+ptr+kind, "", ptr+kind))
+		}
+		b.WriteTo(&buf)
+	}
+
+	if ptrRecv {
+		buf.WriteString("return &cp\n}")
+	} else {
+		buf.WriteString("return cp\n}")
+	}
+
+	if a.allocator {
+		buf.WriteString("\n\n")
+		buf.Write(a.generateWith(obj, x, kind, locks, imports, skips, generating))
+	} else if len(a.options) > 0 {
+		all := make([]string, len(a.options))
+		for i, opt := range a.options {
+			all[i] = opt + ": true"
+		}
+		opts := obj.Obj().Name() + "CopyOptions{" + strings.Join(all, ", ") + "}"
+
+		call := "o.DeepCopyOpts(" + opts + ")"
+		if a.funcs {
+			call = a.funcName(obj, "DeepCopyOpts") + "(o, " + opts + ")"
+		}
+
+		fmt.Fprintf(&buf, `
+
+// %s generates a deep copy of %s%s, with all of its optional members
+%s {
+	return %s
+}`, a.funcName(obj, a.copyMethod()), ptr, kind, a.funcDecl(obj, x, imports, a.copyMethod(), "o "+ptr+kind, "", ptr+kind), call)
+	}
+
+	if a.genShallow {
+		if !a.funcs && a.declaresMethod(p, obj, "ShallowCopy") {
+			a.warnf("%s already has a ShallowCopy method, skipping it", kind)
+		} else if locks {
+			a.warnf("%s contains a lock, skipping its ShallowCopy method", kind)
+		} else if ptrRecv {
+			fmt.Fprintf(&buf, `
+
+// %s generates a shallow copy of *%s
+%s {
+	cp := *o
+	return &cp
+}`, a.funcName(obj, "ShallowCopy"), kind, a.funcDecl(obj, x, imports, "ShallowCopy", "o *"+kind, "", "*"+kind))
+		} else {
+			fmt.Fprintf(&buf, `
+
+// %s generates a shallow copy of %s
+%s {
+	return o
+}`, a.funcName(obj, "ShallowCopy"), kind, a.funcDecl(obj, x, imports, "ShallowCopy", "o "+kind, "", kind))
+		}
+	}
+
+	if a.genEqual {
+		if !a.funcs && a.declaresMethod(p, obj, "DeepEqual") {
+			a.warnf("%s already has a DeepEqual method, skipping it", kind)
+		} else {
+			buf.WriteString("\n\n")
+			buf.Write(a.generateEqual(obj, x, kind, ptrRecv, imports, generating))
+		}
+	}
+
+	if a.genSlice {
+		// The elements are copied as those of any slice of the type, with
+		// its DeepCopy method, or function.
+		name := "DeepCopy" + obj.Obj().Name() + "Slice"
+		fmt.Fprintf(&buf, `
+
+// %s generates a deep copy of the []%s slice
+func %s%s(s []%s) []%s {
+	var cp []%s
+`, name, kind, name, a.typeParams(obj, x, imports), kind, kind, kind)
+		a.walkMethod("s", "cp", x, types.NewSlice(selfInstance(obj)), &buf, imports, nil, generating, 1)
+		buf.WriteString("return cp\n}")
+	}
+
+	return buf.Bytes(), nil
+}
+
+// generateWith returns the DeepCopyWith method of the type, allocating the copy
+// with the hooks of its allocator interface, which are recorded as allocated.
+// The walk meets the warnings and failures of the DeepCopy method again, which
+// are left out.
+func (a *app) generateWith(obj object, x, kind string, locks bool, imports map[string]string, skips map[string]struct{}, generating []object) []byte {
+	var buf bytes.Buffer
+
+	warnings, failures, quiet := a.warnings, a.failures, a.quiet
+	a.quiet = true
+	a.allocating = &allocation{obj: obj, hooks: map[string]string{}}
+	a.allocations = append(a.allocations, a.allocating)
+	defer func() {
+		a.warnings, a.failures, a.quiet = warnings, failures, quiet
+		a.allocating = nil
+	}()
+
+	ptrRecv := a.ptrRecv(obj)
+	var ptr string
+	if ptrRecv {
+		ptr = "*"
+	}
+
+	fmt.Fprintf(&buf, `// DeepCopyWith generates a deep copy of %s%s, allocated by the allocator
+%s {
+`, ptr, kind, a.funcDecl(obj, x, imports, "DeepCopyWith", "o "+ptr+kind, "allocator "+allocatorType(kind), ptr+kind))
+
+	// The copy itself is allocated too, when it is returned as a pointer.
+	source, sink := "o", "cp"
+	if ptrRecv {
+		fmt.Fprintf(&buf, "cp := %s\n", a.newExpr(kind, imports))
+		if locks {
+			a.assignFields(&buf, "o", "cp", obj)
+		} else {
+			buf.WriteString("*cp = *o\n")
+		}
+		if _, ok := obj.Underlying().(*types.Struct); !ok {
+			source, sink = deref(source), deref(sink)
+		}
+	} else {
+		fmt.Fprintf(&buf, "var cp %s = o\n", kind)
+	}
+
+	a.walkMethod(source, sink, x, obj, &buf, imports, skips, generating, 0)
+	buf.WriteString("return cp\n}")
+
+	return buf.Bytes()
+}
+
+// ptrRecv reports whether the generated methods of the type have a pointer
+// receiver, as required by those containing a lock.
+func (a *app) ptrRecv(obj object) bool {
+	return a.isPtrRecv || containsLock(obj)
+}
+
+// localPackage returns the name of the package the generated code belongs to.
+func (a *app) localPackage(p *packages.Package) string {
+	if a.outPackage != "" {
+		return a.outPackage
+	}
+
+	return p.Name
+}
+
+// funcName returns the name of the generated method, or of the function
+// copying the type, when generating functions.
+func (a *app) funcName(obj object, method string) string {
+	if a.funcs {
+		return method + obj.Obj().Name()
+	}
+
+	return method
+}
+
+// funcDecl returns the declaration of the generated method, or function, with
+// the receiver as the first parameter of the function.
+func (a *app) funcDecl(obj object, x string, imports map[string]string, method, recv, params, result string) string {
+	if result != "" {
+		result = " " + result
+	}
+
+	if !a.funcs {
+		return fmt.Sprintf("func (%s) %s(%s)%s", recv, method, params, result)
+	}
+
+	if params != "" {
+		recv += ", " + params
+	}
+
+	return fmt.Sprintf("func %s%s(%s)%s", a.funcName(obj, method), a.typeParams(obj, x, imports), recv, result)
+}
+
+// typeParams returns the type parameter list of a generic type, with their
+// constraints, as declared by a function.
+func (a *app) typeParams(obj object, x string, imports map[string]string) string {
+	named, ok := obj.(*types.Named)
+	if !ok || named.TypeParams().Len() == 0 {
+		return ""
+	}
+
+	params := make([]string, named.TypeParams().Len())
+	for i := range params {
+		tp := named.TypeParams().At(i)
+		params[i] = tp.Obj().Name() + " " + a.getElemType(tp.Constraint(), x, imports)
+	}
+
+	return "[" + strings.Join(params, ", ") + "]"
+}
+
+// qualifiedTypeName returns the name of the type with its type parameters,
+// qualified by its package, unless it is the local one.
+func (a *app) qualifiedTypeName(obj object, x string, imports map[string]string) string {
+	if pkg := a.qualifier(x, imports)(obj.Obj().Pkg()); pkg != "" {
+		return pkg + "." + typeName(obj)
+	}
+
+	return typeName(obj)
+}
+
+// selfInstance returns the generic type instantiated with its own type
+// parameters, as in the body of its methods, or the type itself otherwise.
+func selfInstance(obj object) types.Type {
+	named, ok := obj.(*types.Named)
+	if !ok || named.TypeParams().Len() == 0 {
+		return obj
+	}
+
+	args := make([]types.Type, named.TypeParams().Len())
+	for i := range args {
+		args[i] = named.TypeParams().At(i)
+	}
+
+	t, err := types.Instantiate(nil, named, args, false)
+	if err != nil {
+		return obj
+	}
+
+	return t
+}
+
+// typeName returns the name of the type as used in a receiver, with the names
+// of its type parameters, if any. Receivers can't repeat the constraints.
+func typeName(obj object) string {
+	name := obj.Obj().Name()
+
+	named, ok := obj.(*types.Named)
+	if !ok || named.TypeParams().Len() == 0 {
+		return name
+	}
+
+	params := make([]string, named.TypeParams().Len())
+	for i := range params {
+		params[i] = named.TypeParams().At(i).Obj().Name()
+	}
+
+	return name + "[" + strings.Join(params, ", ") + "]"
+}
+
+// declaresMethod reports whether the type already has a method with the given
+// name, which isn't declared in the output file that is to be overwritten.
+func (a *app) declaresMethod(p *packages.Package, obj object, name string) bool {
+	sel := types.NewMethodSet(types.NewPointer(obj)).Lookup(obj.Obj().Pkg(), name)
+	if sel == nil || len(sel.Index()) > 1 {
+		return false
+	}
+
+	if a.output == "" {
+		return true
+	}
+
+	output, err := filepath.Abs(a.output)
+
+	return err != nil || p.Fset.Position(sel.Obj().Pos()).Filename != output
+}
+
+// generateFile returns the formatted file of the generated functions, with the
+// imports by name, and the actual names of the imported packages by path. The
+// file is returned unformatted with noFormat.
+func generateFile(pkg, license string, imports, pkgNames map[string]string, fn [][]byte, noFormat bool) ([]byte, error) {
+	var file bytes.Buffer
+
+	file.WriteString(license)
+	fmt.Fprintf(&file, "// generated by %s; DO NOT EDIT.\n\npackage %s\n\n", strings.Join(os.Args, " "), pkg)
+
+	if len(imports) > 0 {
+		names := make([]string, 0, len(imports))
+		for name := range imports {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			return imports[names[i]] < imports[names[j]]
+		})
+
+		file.WriteString("import (\n")
+		for _, name := range names {
+			// Packages named other than their path are imported with their
+			// name, such as those of vendored modules with a "go-" prefix,
+			// as are those imported with an alias.
+			imp := imports[name]
+			actual, ok := pkgNames[imp]
+			if !ok {
+				actual = path.Base(imp)
+			}
+			if path.Base(imp) == name && actual == name {
+				fmt.Fprintf(&file, "%q\n", imp)
+			} else {
+				fmt.Fprintf(&file, "%s %q\n", name, imp)
+			}
+		}
+		file.WriteString(")\n")
+	}
+
+	for _, fn := range fn {
+		file.Write(fn)
+		file.WriteString("\n\n")
+	}
+
+	if noFormat {
+		return file.Bytes(), nil
 	}
 
 	b, err := format.Source(file.Bytes())
@@ -262,321 +1621,1620 @@ func generateFile(p *packages.Package, imports map[string]string, fn [][]byte) (
 		return nil, fmt.Errorf("error formatting source: %w\nsource:\n%s", err, file.String())
 	}
 
-	return b, nil
-}
+	return b, nil
+}
+
+// jsonResult is the JSON output of the generated code, with its metadata.
+type jsonResult struct {
+	Code     string   `json:"code"`
+	Imports  []string `json:"imports"`
+	Warnings []string `json:"warnings"`
+}
+
+// jsonOutput returns the generated code as a JSON object, together with the
+// paths of its imports, and the warnings about it.
+func jsonOutput(code []byte, warnings []string) ([]byte, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), "", code, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	result := jsonResult{Code: string(code), Imports: []string{}, Warnings: warnings}
+	for _, spec := range f.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+		result.Imports = append(result.Imports, importPath)
+	}
+	if result.Warnings == nil {
+		result.Warnings = []string{}
+	}
+
+	b, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, '\n'), nil
+}
+
+// parseImportMap parses the lines of an import map, each an import path and
+// the alias it is imported with. Blank lines, and those starting with a "#",
+// are ignored.
+func parseImportMap(r io.Reader) (map[string]string, error) {
+	aliases := map[string]string{}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 || !token.IsIdentifier(fields[1]) || fields[1] == "_" {
+			return nil, fmt.Errorf("line %d: expected an import path and an alias, got %q", n, line)
+		}
+		aliases[fields[0]] = fields[1]
+	}
+
+	return aliases, scanner.Err()
+}
+
+// licenseHeader returns the license text as a comment preceding the generated
+// file, substituting the copyright year and holder in the text template.
+func licenseHeader(text string, year int, holder string) (string, error) {
+	tmpl, err := template.New("license").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parsing license template: %v", err)
+	}
+
+	var b strings.Builder
+	err = tmpl.Execute(&b, struct {
+		Year   int
+		Holder string
+	}{year, holder})
+	if err != nil {
+		return "", fmt.Errorf("executing license template: %v", err)
+	}
+
+	var header strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(b.String(), "\n"), "\n") {
+		header.WriteString(strings.TrimRight("// "+line, " ") + "\n")
+	}
+	header.WriteString("\n")
+
+	return header.String(), nil
+}
+
+type object interface {
+	types.Type
+	Obj() *types.TypeName
+}
+
+type pointer interface {
+	Elem() types.Type
+}
+
+type methoder interface {
+	types.Type
+	Method(i int) *types.Func
+	NumMethods() int
+}
+
+func locateType(x, sel string, p *packages.Package) (object, error) {
+	for _, t := range p.TypesInfo.Defs {
+		if t == nil {
+			continue
+		}
+		m := exprFilter(t.Type(), sel, x)
+		if m == nil {
+			continue
+		}
+
+		return m, nil
+	}
+
+	return nil, errors.New("type not found")
+}
+
+func reducePointer(typ types.Type) (types.Type, bool) {
+	if pointer, ok := typ.(pointer); ok {
+		return pointer.Elem(), true
+	}
+	return typ, false
+}
+
+func objFromType(typ types.Type) object {
+	typ, _ = reducePointer(typ)
+
+	m, ok := typ.(object)
+	if !ok {
+		return nil
+	}
+
+	return m
+}
+
+func exprFilter(t types.Type, sel string, x string) object {
+	if _, ok := t.(*types.TypeParam); ok {
+		return nil
+	}
+
+	m := objFromType(t)
+	if m == nil {
+		return nil
+	}
+
+	obj := m.Obj()
+	if obj.Pkg() == nil || x != obj.Pkg().Name() || sel != obj.Name() {
+		return nil
+	}
+
+	// Fields may refer to instantiations of a generic type, the methods are
+	// generated for the generic type itself.
+	if named, ok := m.(*types.Named); ok {
+		return named.Origin()
+	}
+
+	return m
+}
+
+// walkMethod walks the type a copy is generated for, declaring first the map of
+// the pointers it has already copied, when it has to preserve their aliasing.
+func (a *app) walkMethod(source, sink, x string, t types.Type, w io.Writer, imports map[string]string, skips skips, generating []object, depth int) {
+	var b bytes.Buffer
+	a.aliased = false
+	a.walkType(source, sink, x, t, &b, imports, skips, generating, depth)
+	if a.aliased {
+		fmt.Fprintf(w, "visited := make(map[any]any)\n")
+	}
+	b.WriteTo(w)
+}
+
+func (a *app) walkType(source, sink, x string, m types.Type, w io.Writer, imports map[string]string, skips skips, generating []object, depth int) {
+	initial := depth == 0
+	if m == nil {
+		return
+	}
+
+	// Aliases, even chained ones, are copied as the type they denote, while
+	// types are still spelled as written.
+	resolved := types.Unalias(m)
+	if isValueType(resolved) || a.isSkippedType(resolved) {
+		return
+	}
+
+	if a.maxDepth > 0 {
+		sinkDepth := strings.Count(sink, ".")
+		if sinkDepth >= a.maxDepth {
+			p := strings.Split(sink, ".")
+			stoppedAt := strings.TrimSuffix(fmt.Sprintf("%s.%s", generating[0], strings.Join(p[1:len(p)-1], ".")), ".")
+			a.warnf("reached max depth %d. stop recursion at %s", sinkDepth, stoppedAt)
+			return
+		}
+	}
+
+	var needExported bool
+	switch v := resolved.(type) {
+	case *types.Named:
+		if v.Obj().Pkg() != nil && !a.isLocal(v.Obj().Pkg()) {
+			needExported = true
+		}
+	}
+
+	if !initial {
+		if obj := a.staleType(resolved); obj != nil {
+			generating = append(generating[:len(generating):len(generating)], obj)
+		}
+	}
+
+	if v, ok := resolved.(methoder); ok && !initial && a.reuseDeepCopy(source, sink, x, v, false, imports, generating, a.explained(w, "reused DeepCopy")) {
+		return
+	}
+
+	// Types reached again while being inlined are copied by a method of their
+	// own, called recursively, instead of being inlined forever.
+	if obj, recursive := a.recursiveType(resolved); recursive && !initial {
+		if obj == nil || !a.reuseDeepCopy(source, sink, x, resolved.(methoder), false, imports, append(generating[:len(generating):len(generating)], obj), w) {
+			a.warnf("%s is of recursive type %s without a DeepCopy method, its value is shared", a.selector(sink), types.TypeString(resolved, (*types.Package).Name))
+		}
+		return
+	}
+	if named, ok := resolved.(*types.Named); ok {
+		a.expanding = append(a.expanding, named)
+		defer func() {
+			a.expanding = a.expanding[:len(a.expanding)-1]
+		}()
+	}
+
+	if v, ok := resolved.(*types.TypeParam); ok {
+		// Values of type parameters are copied by assignment, unless their
+		// constraint provides a DeepCopy method.
+		if iface, ok := v.Underlying().(*types.Interface); ok {
+			method := a.ifaceCopyMethod()
+			if found, assert := interfaceDeepCopy(v, iface, method); found {
+				w = a.explained(w, "%s of the constraint of %s", method, v)
+				if assert {
+					fmt.Fprintf(w, "%s = %s.(%s)\n", sink, selectField(source, method+"()"), a.getElemType(v, x, imports))
+				} else {
+					fmt.Fprintf(w, "%s = %s\n", sink, selectField(source, method+"()"))
+				}
+				return
+			}
+		}
+		if !types.Comparable(v) {
+			a.warnf("%s is of type parameter %s, its value is shared", a.selector(sink), v)
+		}
+		return
+	}
+
+	depth++
+	under := m.Underlying()
+	switch v := under.(type) {
+	case *types.Struct:
+		for i := 0; i < v.NumFields(); i++ {
+			field := v.Field(i)
+			if needExported && !field.Exported() {
+				continue
+			}
+			fname := field.Name()
+			// Locks, and noCopy markers, are left zero in the copy.
+			if isLock(field.Type()) {
+				continue
+			}
+			sel := a.skipSelector(sink + "." + fname)
+			switch a.fieldTag(v, i) {
+			case "skip":
+				if !a.isDeep(sel) {
+					continue
+				}
+			case "zero":
+				fmt.Fprintf(a.explained(w, "zeroed by tag"), "%s = %s\n", selectField(sink, fname), a.zeroValue(field.Type(), x, imports))
+				continue
+			}
+			if a.isSkipped(skips, sel) {
+				continue
+			}
+			if opt := a.optionField(sel); opt != "" {
+				// Members copied by assignment alone need no option.
+				var b bytes.Buffer
+				a.walkType(selectField(source, fname), selectField(sink, fname), x, field.Type(), &b, imports, skips, generating, depth)
+				if b.Len() > 0 {
+					if !contains(a.options, opt) {
+						a.options = append(a.options, opt)
+					}
+					fmt.Fprintf(a.explained(w, "optional member"), "if opts.%s {\n", opt)
+					b.WriteTo(w)
+					fmt.Fprintf(w, "}\n")
+				}
+				continue
+			}
+			a.walkType(selectField(source, fname), selectField(sink, fname), x, field.Type(), w, imports, skips, generating, depth)
+		}
+	case *types.Slice:
+		kind := a.getElemType(v.Elem(), x, imports)
+
+		idx := "i"
+		if depth > 1 {
+			idx += strconv.Itoa(depth)
+		}
+
+		// sel is only used for skips. Skipping the elements still copies
+		// them, as the backing array isn't shared, without copying their
+		// members.
+		sel := "[i]"
+		if !initial {
+			sel = a.skipSelector(sink + sel)
+		}
+
+		var skipSlice bool
+		if a.isSkipped(skips, sel) {
+			skipSlice = true
+		}
+
+		w = a.explained(w, "deep copy: slice of %s", a.explainedType(v.Elem()))
+
+		if !a.nilSliceAsEmpty {
+			fmt.Fprintf(w, "if %s != nil {\n", source)
+		}
+
+		// The pointed-to elements of reused slices of pointers are reused
+		// too, the new ones only are allocated.
+		var reuseElems bool
+		if a.reuse && a.isReusable(sink) {
+			a.reused = append(a.reused, sink)
+			prev := prevIdent(sink)
+			_, reuseElems = types.Unalias(v.Elem()).(*types.Pointer)
+			reuseElems = reuseElems && !skipSlice
+
+			// Reusing the very array of the source, as after out := *o,
+			// would leave the copy aliasing it.
+			fmt.Fprintf(w, `if len(%s) > 0 && cap(%s) > 0 && &%s[:1][0] == &%s {
+	%s = nil
+}
+`, source, prev, prev, index(source, "0"), prev)
+
+			// A nil slice of out would copy empty slices as nil.
+			fmt.Fprintf(w, `if %s != nil && cap(%s) >= len(%s) {
+	%s = %s[:len(%s)]
+} else {
+	%s = %s
+`, prev, prev, source, sink, prev, source, sink, a.makeSliceExpr("[]"+kind, source, imports))
+			if reuseElems {
+				fmt.Fprintf(w, "copy(%s, %s)\n", sink, prev)
+			}
+			fmt.Fprintf(w, "}\n")
+		} else {
+			fmt.Fprintf(w, "%s = %s\n", sink, a.makeSliceExpr("[]"+kind, source, imports))
+		}
+
+		if reuseElems {
+			a.copyElemsInto(source, sink, idx, x, types.Unalias(v.Elem()).(*types.Pointer), w, imports, skips, generating, depth)
+
+			if !a.nilSliceAsEmpty {
+				fmt.Fprintf(w, "}\n")
+			}
+			break
+		}
+
+		fmt.Fprintf(w, `copy(%s, %s)
+`, sink, source)
+
+		var b bytes.Buffer
+
+		if !skipSlice {
+			a.walkType(index(source, idx), index(sink, idx), x, v.Elem(), &b, imports, skips, generating, depth)
+		}
+
+		if b.Len() > 0 {
+			fmt.Fprintf(w, `    for %s := range %s {
+`, idx, source)
+
+			b.WriteTo(w)
+
+			fmt.Fprintf(w, "}\n")
+		}
+
+		if !a.nilSliceAsEmpty {
+			fmt.Fprintf(w, "}\n")
+		}
+	case *types.Array:
+		idx := "i"
+		if depth > 1 {
+			idx += strconv.Itoa(depth)
+		}
+
+		// sel is only used for skips
+		sel := "[i]"
+		if !initial {
+			sel = a.skipSelector(sink + sel)
+		}
+
+		if a.isSkipped(skips, sel) {
+			break
+		}
+
+		// The elements are already copied with the array value, only their
+		// references need copying.
+		var b bytes.Buffer
+		a.walkType(index(source, idx), index(sink, idx), x, v.Elem(), &b, imports, skips, generating, depth)
+
+		w = a.explained(w, "deep copy: references of array of %s", a.explainedType(v.Elem()))
+		if b.Len() > 0 {
+			fmt.Fprintf(w, "for %s := range %s {\n", idx, source)
+			b.WriteTo(w)
+			fmt.Fprintf(w, "}\n")
+		}
+	case *types.Pointer:
+		fmt.Fprintf(a.explained(w, "deep copy: pointer to %s", a.explainedType(v.Elem())), "if %s != nil {\n", source)
+
+		// Pointers already copied are copied to the same pointer.
+		end := "}\n"
+		if a.aliasing && !initial {
+			a.aliased = true
+			fmt.Fprintf(a.explained(w, "preserved aliasing"), "if p, ok := visited[%s]; ok {\n%s = p.(%s)\n} else {\n", source, sink, a.getElemType(v, x, imports))
+			end = fmt.Sprintf("visited[%s] = %s\n}\n}\n", source, sink)
+		}
+
+		// Pointers to recursive types are copied by their own method too.
+		egenerating := generating
+		if obj, _ := a.recursiveType(types.Unalias(v.Elem())); obj != nil {
+			egenerating = append(generating[:len(generating):len(generating)], obj)
+		} else if obj := a.staleType(types.Unalias(v.Elem())); obj != nil {
+			egenerating = append(generating[:len(generating):len(generating)], obj)
+		}
+
+		if e, ok := types.Unalias(v.Elem()).(methoder); !ok || initial || !a.reuseDeepCopy(source, sink, x, e, true, imports, egenerating, a.explained(w, "reused DeepCopy")) {
+			kind := a.getElemType(v.Elem(), x, imports)
+
+			fmt.Fprintf(w, "%s = %s\n", sink, a.newExpr(kind, imports))
+			if containsLock(v.Elem()) {
+				a.assignFields(w, source, sink, v.Elem())
+			} else {
+				fmt.Fprintf(w, "*%s = *%s\n", sink, source)
+			}
+
+			// Struct fields are selected through the pointer, anything else
+			// is copied into the pointed-to value.
+			esource, esink := source, sink
+			if _, ok := v.Elem().Underlying().(*types.Struct); !ok {
+				esource, esink = deref(source), deref(sink)
+			}
+
+			a.walkType(esource, esink, x, v.Elem(), w, imports, skips, generating, depth)
+		}
+
+		fmt.Fprint(w, end)
+	case *types.Chan:
+		if a.dropChan {
+			fmt.Fprintf(a.explained(w, "dropped channel"), "%s = nil\n", sink)
+			break
+		}
+
+		// A new directional channel would be disconnected from any other
+		// end, nothing could be sent to, or received from it, so they are
+		// shared with the copy.
+		if v.Dir() != types.SendRecv {
+			break
+		}
+
+		kind := a.getElemType(v.Elem(), x, imports)
+
+		// Buffered values are never copied, which is easily overlooked for
+		// references, such as slices.
+		if isReference(v.Elem()) {
+			a.warnf("%s is a channel of reference type %s, its buffered values aren't copied", a.selector(sink), types.TypeString(v.Elem(), (*types.Package).Name))
+		}
+
+		fmt.Fprintf(a.explained(w, "new channel of the same capacity, without the buffered values"), `if %s != nil {
+	%s = make(chan %s, cap(%s))
+}
+`, source, sink, kind, source)
+	case *types.Signature:
+		// Functions are shared, whatever their signature. Its parameter and
+		// result types are never walked, nor imported.
+	case *types.Interface:
+		method := a.ifaceCopyMethod()
+		found, assert := interfaceDeepCopy(m, v, method)
+		if !found {
+			if impls := a.implsOf(m); len(impls) > 0 {
+				a.copyImpls(source, sink, x, impls, a.explained(w, "type switch over the implementations of %s", a.explainedType(m)), imports, skips, generating, depth)
+				break
+			}
+			if sel := strings.TrimPrefix(a.selector(sink), a.current.Obj().Name()+"."); a.isDeep(sel) {
+				a.failures = append(a.failures, fmt.Sprintf("%s is to be deep copied, but interface type %s has neither a %s method, nor implementations", a.selector(sink), types.TypeString(m, (*types.Package).Name), method))
+				break
+			}
+			// Errors, and the interfaces extending error, are immutable by
+			// convention, they are safely shared.
+			isError := types.AssignableTo(m, errorType)
+			if a.ifaceDynamic && !v.Empty() && !isError {
+				a.dynamicCopy(source, sink, x, m, w, imports)
+				break
+			}
+			if !isError {
+				a.warnf("%s is of interface type %s without a %s method, its value is shared", a.selector(sink), types.TypeString(m, (*types.Package).Name), method)
+			}
+			break
+		}
+
+		fmt.Fprintf(a.explained(w, "%s of interface %s", method, a.explainedType(m)), "if %s != nil {\n", source)
+		if assert {
+			fmt.Fprintf(w, "%s = %s.(%s)\n", sink, selectField(source, method+"()"), a.getElemType(m, x, imports))
+		} else {
+			fmt.Fprintf(w, "%s = %s\n", sink, selectField(source, method+"()"))
+		}
+		fmt.Fprintf(w, "}\n")
+	case *types.Map:
+		kkind := a.getElemType(v.Key(), x, imports)
+		vkind := a.getElemType(v.Elem(), x, imports)
+
+		key, val := "k", "v"
+
+		if depth > 1 {
+			key += strconv.Itoa(depth)
+			val += strconv.Itoa(depth)
+		}
+
+		// Sel is only used for skips
+		sel := "[k]"
+		if !initial {
+			sel = a.skipSelector(sink + sel)
+		}
+
+		var skipKey, skipValue bool
+		if a.isSkipped(skips, sel) {
+			skipKey, skipValue = true, true
+		}
+
+		// Dropped channels are neither copied nor shared, as keys they would
+		// all collapse into a single nil one.
+		_, chanKey := v.Key().Underlying().(*types.Chan)
+		_, chanValue := v.Elem().Underlying().(*types.Chan)
+		dropKey := a.dropChan && chanKey
+		dropValue := a.dropChan && chanValue && !skipValue
+
+		// Empty struct values, as those of sets, hold nothing to copy.
+		emptyValue := isEmptyStruct(v.Elem())
+
+		w = a.explained(w, "deep copy: map of %s to %s", a.explainedType(v.Key()), a.explainedType(v.Elem()))
+
+		if !a.nilMapAsEmpty {
+			fmt.Fprintf(w, "if %s != nil {\n", source)
+		}
+
+		// Named map types are made as such, rather than converted from
+		// their underlying map type.
+		mkind := "map[" + kkind + "]" + vkind
+		if _, ok := m.(*types.Map); !ok {
+			t := m
+			if obj, ok := m.(object); ok && initial {
+				t = selfInstance(obj)
+			}
+			mkind = a.getElemType(t, x, imports)
+		}
+
+		fmt.Fprintf(w, "%s = %s\n", sink, a.makeExpr("MakeMap", mkind, "len("+source+")", imports))
+		if dropValue || emptyValue {
+			fmt.Fprintf(w, "for %s := range %s {\n", key, source)
+		} else {
+			fmt.Fprintf(w, "for %s, %s := range %s {\n", key, val, source)
+		}
+
+		ksink, vsink := key, val
+		if a.valueSinks == nil {
+			a.valueSinks = map[string]string{}
+		}
+
+		var b bytes.Buffer
+
+		// Keys are shared by default: map lookups compare pointers by
+		// identity, so keys holding deep copied pointers would no longer
+		// match those of the source.
+		if !skipKey && !dropKey && a.deepKeys {
+			copyKSink := selToIdent(sink) + "_" + key
+			a.valueSinks[copyKSink] = index(sink, key)
+			a.walkType(key, copyKSink, x, v.Key(), &b, imports, skips, generating, depth)
+
+			if b.Len() > 0 {
+				ksink = copyKSink
+				a.declareCopy(w, ksink, kkind, key, v.Key(), b.Bytes())
+				b.WriteTo(w)
+			}
+		}
+
+		b.Reset()
+
+		if dropValue {
+			vsink = "nil"
+		} else if emptyValue {
+			vsink = a.zeroValue(v.Elem(), x, imports)
+		} else if !skipValue {
+			copyVSink := selToIdent(sink) + "_" + val
+			a.valueSinks[copyVSink] = index(sink, key)
+			a.walkType(val, copyVSink, x, v.Elem(), &b, imports, skips, generating, depth)
+
+			if b.Len() > 0 {
+				vsink = copyVSink
+				a.declareCopy(w, vsink, vkind, val, v.Elem(), b.Bytes())
+				b.WriteTo(w)
+			}
+		}
+
+		fmt.Fprintf(w, "%s = %s", index(sink, ksink), vsink)
+
+		fmt.Fprintf(w, "}\n")
+
+		if !a.nilMapAsEmpty {
+			fmt.Fprintf(w, "}\n")
+		}
+	}
+
+}
+
+// skipSelector returns the selector of the copied member, as matched by the
+// skips, relative to the type being generated, with its indexes in the "[i]"
+// and "[k]" forms, such as "Field.Slice[i]". Members of map values, copied to
+// variables, are selected through the map, such as "Field[k].Slice[i]".
+//
+// Such members are also matched as if of the type being generated, such as
+// "Slice[i]", as they were before being selected through the map, which is
+// recorded in mapValueSels.
+func (a *app) skipSelector(sink string) string {
+	sel := strings.TrimPrefix(a.selector(sink), a.current.Obj().Name())
+	sel = strings.TrimPrefix(sel, ".")
+
+	prev := indexRe.ReplaceAllString(derefReplacer.Replace(sink), "[$1]")
+	if prev = prev[strings.Index(prev, ".")+1:]; prev != sel {
+		if a.mapValueSels == nil {
+			a.mapValueSels = map[string]string{}
+		}
+		a.mapValueSels[sel] = prev
+	}
+
+	return sel
+}
+
+// isSkipped reports whether the selector is to be shallow copied, either due to
+// the per-type skips, to one of the -skip-all patterns, or to the -skip-expr
+// expression, unless it is to be deep copied.
+func (a *app) isSkipped(skips skips, sel string) bool {
+	if skips.Contains(sel) {
+		return true
+	}
+	if prev, ok := a.mapValueSels[sel]; ok && skips.Contains(prev) {
+		return true
+	}
+
+	if a.isDeep(sel) {
+		return false
+	}
+
+	if a.skipAllMatch(sel) {
+		return true
+	}
+
+	return a.skipExpr != nil && a.skipExpr.Match(sel)
+}
+
+// skipAllMatch reports whether the selector matches one of the -skip-all
+// patterns, either as a glob with the wildcards of -skip, such as "*.logger",
+// or as a regular expression, such as ".*Cache", as -type patterns are.
+func (a *app) skipAllMatch(sel string) bool {
+	if a.skipAllRes == nil {
+		a.skipAllRes = make([]*regexp.Regexp, len(a.skipAll))
+		for i, pattern := range a.skipAll {
+			a.skipAllRes[i], _ = regexp.Compile("^(?:" + pattern + ")$")
+		}
+	}
+
+	for i, pattern := range a.skipAll {
+		if matchSelector(pattern, sel) {
+			return true
+		}
+		if re := a.skipAllRes[i]; re != nil && re.MatchString(sel) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// optionField returns the field of the DeepCopyOpts options gating the deep
+// copy of the selector, such as CopyFieldCache for Field.Cache, or an empty
+// string if it isn't optional.
+func (a *app) optionField(sel string) string {
+	if !a.runtimeOpts || !contains(a.optional, sel) {
+		return ""
+	}
+
+	opt := "Copy"
+	for _, name := range strings.Split(sel, ".") {
+		r, size := utf8.DecodeRuneInString(name)
+		opt += string(unicode.ToUpper(r)) + name[size:]
+	}
+
+	return opt
+}
+
+// isDeep reports whether the selector is to be deep copied, whether skipped
+// otherwise or not.
+func (a *app) isDeep(sel string) bool {
+	for _, deep := range a.deep {
+		if deep == sel {
+			return true
+		}
+	}
+
+	return false
+}
+
+// declareCopy declares the variable holding the copy of a map key or value,
+// copied by the statements of body. Structs and arrays are only partially
+// copied by walkType, as are interfaces copied by a type switch over their
+// implementations, so the variable is initialized from the source, unless the
+// first statement assigns it whole, whereas the other kinds are either
+// entirely assigned, or left nil as their source.
+func (a *app) declareCopy(w io.Writer, sink, kind, source string, t types.Type, body []byte) {
+	initialized := false
+	switch t.Underlying().(type) {
+	case *types.Struct, *types.Array:
+		initialized = true
+	case *types.Interface:
+		initialized = len(a.implsOf(t)) > 0
+	}
+
+	if initialized && !assignsWhole(body, sink) {
+		fmt.Fprintf(w, "var %s %s = %s\n", sink, kind, source)
+	} else {
+		fmt.Fprintf(w, "var %s %s\n", sink, kind)
+	}
+}
+
+// assignsWhole reports whether the first statement of body assigns the sink
+// whole, from an expression not reading it.
+func assignsWhole(body []byte, sink string) bool {
+	line, _, _ := strings.Cut(string(body), "\n")
+	rhs, ok := strings.CutPrefix(line, sink+" = ")
+
+	return ok && !strings.Contains(rhs, sink)
+}
+
+func (a *app) getElemType(t types.Type, x string, imports map[string]string) string {
+	return types.TypeString(t, a.qualifier(x, imports))
+}
+
+// qualifier qualifies the types of packages other than the local one,
+// recording their imports. Packages named as another import, or as the local
+// package x, are imported with an alias derived from their path.
+func (a *app) qualifier(x string, imports map[string]string) types.Qualifier {
+	return func(p *types.Package) string {
+		if a.isLocal(p) {
+			return ""
+		}
+
+		name := p.Name()
+		if alias, ok := a.importAliases[p.Path()]; ok {
+			name = alias
+		}
+		taken := func(name string) bool {
+			imp, ok := imports[name]
+			return name == x || ok && imp != p.Path()
+		}
+		for _, alias := range []string{identifier(path.Base(p.Path())), identifier(p.Path())} {
+			if !taken(name) {
+				break
+			}
+			name = alias
+		}
+		imports[name] = p.Path()
+		if a.pkgNames == nil {
+			a.pkgNames = map[string]string{}
+		}
+		a.pkgNames[p.Path()] = p.Name()
+		return name
+	}
+}
+
+// isLocal reports whether the package is the one the code is generated in,
+// by its path, as other packages may have the same name.
+func (a *app) isLocal(p *types.Package) bool {
+	return p != nil && a.localPath != "" && p.Path() == a.localPath
+}
+
+// identifier returns the import path as an identifier, replacing any
+// character not allowed in one with an underscore.
+func identifier(importPath string) string {
+	id := []rune(importPath)
+	for i, r := range id {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			id[i] = '_'
+		}
+	}
+
+	return string(id)
+}
+
+// generatingType returns the type being generated that is identical to t, if
+// any. As the methods of a generic type are those of all its instantiations,
+// including its recursive references, they match their generic type.
+func generatingType(t types.Type, generating []object) object {
+	t = types.Unalias(t)
+	if named, ok := t.(*types.Named); ok {
+		t = named.Origin()
+	}
+
+	for _, obj := range generating {
+		if types.Identical(t, obj) {
+			return obj
+		}
+	}
+
+	return nil
+}
+
+func (a *app) hasDeepCopy(v methoder, generating []object) (hasMethod, isPointer bool) {
+	// The method being generated replaces any existing, possibly stale, one,
+	// so its receiver kind takes precedence.
+	if obj := generatingType(v, generating); obj != nil {
+		return true, a.ptrRecv(obj)
+	}
+
+	for i := 0; i < v.NumMethods(); i++ {
+		m := v.Method(i)
+		if m.Name() != a.copyMethod() {
+			continue
+		}
+
+		sig, ok := m.Type().(*types.Signature)
+		if !ok {
+			continue
+		}
+
+		if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+			continue
+		}
+
+		ret := sig.Results().At(0)
+		retType, retPointer := reducePointer(ret.Type())
+		sigType, _ := reducePointer(sig.Recv().Type())
+
+		if !types.Identical(retType, sigType) {
+			return false, false
+		}
+
+		return true, retPointer
+	}
+
+	return false, false
+}
+
+var (
+	errorType = types.Universe.Lookup("error").Type()
+	indexRe   = regexp.MustCompile(`\[([ik])\d*\]`)
+
+	// derefReplacer removes the dereferences from a selector.
+	derefReplacer = strings.NewReplacer("(", "", ")", "", "*", "")
+
+	// valueTypes are the qualified names of the types, which are safely
+	// copied by assignment, their internals are never walked.
+	valueTypes = map[string]bool{
+		"time.Time": true,
+		"embed.FS":  true,
+
+		"database/sql.NullBool":    true,
+		"database/sql.NullByte":    true,
+		"database/sql.NullFloat64": true,
+		"database/sql.NullInt16":   true,
+		"database/sql.NullInt32":   true,
+		"database/sql.NullInt64":   true,
+		"database/sql.NullString":  true,
+		"database/sql.NullTime":    true,
+	}
+)
+
+// isSkippedType reports whether t is one of the types given with -skip-type.
+func (a *app) isSkippedType(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+
+	return contains(a.skipTypes, named.Obj().Pkg().Path()+"."+named.Obj().Name())
+}
+
+// isEmptyStruct reports whether t is a struct without fields, such as struct{}.
+func isEmptyStruct(t types.Type) bool {
+	v, ok := t.Underlying().(*types.Struct)
+
+	return ok && v.NumFields() == 0
+}
+
+// isReference reports whether t is of a reference kind: a slice, map, pointer,
+// channel or interface.
+func isReference(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Slice, *types.Map, *types.Pointer, *types.Chan, *types.Interface:
+		return true
+	}
+
+	return false
+}
+
+// isValueType reports whether t is one of the value types.
+func isValueType(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+
+	return valueTypes[named.Obj().Pkg().Path()+"."+named.Obj().Name()]
+}
+
+// copyMethod returns the name of the generated method, and of those of the
+// members reused.
+func (a *app) copyMethod() string {
+	if a.method == "" {
+		return "DeepCopy"
+	}
+
+	return a.method
+}
+
+// ifaceCopyMethod returns the name of the method deep copying the values of
+// interfaces.
+func (a *app) ifaceCopyMethod() string {
+	if a.ifaceMethod == "" {
+		return "DeepCopy"
+	}
+
+	return a.ifaceMethod
+}
+
+// selector returns the sink in a form suitable for messages, relative to the
+// type being generated.
+func (a *app) selector(sink string) string {
+	// Map values are copied to variables, which stand for the values.
+	for {
+		i := strings.IndexAny(sink, ".[")
+		if i < 0 {
+			i = len(sink)
+		}
+		sel, ok := a.valueSinks[derefReplacer.Replace(sink[:i])]
+		if !ok {
+			break
+		}
+		sink = sel + sink[i:]
+	}
+
+	sel := derefReplacer.Replace(sink)
+	sel = indexRe.ReplaceAllString(sel, "[$1]")
+	if i := strings.IndexAny(sel, ".["); i >= 0 {
+		sel = sel[i:]
+	} else {
+		sel = ""
+	}
+
+	return a.current.Obj().Name() + sel
+}
+
+// newExpr returns the expression allocating a new value of the type kind.
+func (a *app) newExpr(kind string, imports map[string]string) string {
+	if a.allocating == nil {
+		return "new(" + kind + ")"
+	}
+
+	return a.allocatorHook("New"+hookName(kind), "", "*"+kind, "")
+}
+
+// isLock reports whether the type is a lock, or a noCopy marker, that go vet
+// reports copies of: a struct with pointer Lock and Unlock methods.
+func isLock(t types.Type) bool {
+	if _, ok := t.Underlying().(*types.Struct); !ok {
+		return false
+	}
+
+	mset := types.NewMethodSet(types.NewPointer(t))
+	for _, name := range []string{"Lock", "Unlock"} {
+		sel := mset.Lookup(nil, name)
+		if sel == nil {
+			return false
+		}
+		if sig, ok := sel.Type().(*types.Signature); !ok || sig.Params().Len() != 0 || sig.Results().Len() != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// containsLock reports whether a value of the type contains a lock, directly
+// or in one of its struct fields, or array elements.
+func containsLock(t types.Type) bool {
+	if isLock(t) {
+		return true
+	}
+
+	switch v := t.Underlying().(type) {
+	case *types.Struct:
+		for i := 0; i < v.NumFields(); i++ {
+			if containsLock(v.Field(i).Type()) {
+				return true
+			}
+		}
+	case *types.Array:
+		return containsLock(v.Elem())
+	}
+
+	return false
+}
+
+// assignFields writes the assignment of each struct field of the source to the
+// sink, except for the locks. The fields of nested structs containing a lock
+// are assigned in turn, while arrays of them are left zero.
+func (a *app) assignFields(w io.Writer, source, sink string, t types.Type) {
+	var needExported bool
+	if named, ok := types.Unalias(t).(*types.Named); ok && named.Obj().Pkg() != nil && !a.isLocal(named.Obj().Pkg()) {
+		needExported = true
+	}
+
+	v := t.Underlying().(*types.Struct)
+	for i := 0; i < v.NumFields(); i++ {
+		field := v.Field(i)
+		if field.Name() == "_" || needExported && !field.Exported() {
+			continue
+		}
 
-type object interface {
-	types.Type
-	Obj() *types.TypeName
+		fsource, fsink := selectField(source, field.Name()), selectField(sink, field.Name())
+		_, isStruct := field.Type().Underlying().(*types.Struct)
+		if !containsLock(field.Type()) {
+			fmt.Fprintf(w, "%s = %s\n", fsink, fsource)
+		} else if isStruct && !isLock(field.Type()) {
+			a.assignFields(w, fsource, fsink, field.Type())
+		}
+	}
 }
 
-type pointer interface {
-	Elem() types.Type
-}
+// makeExpr returns the expression making a new slice, or map, of the type kind
+// with the given size, with the allocator hook otherwise.
+func (a *app) makeExpr(method, kind, size string, imports map[string]string) string {
+	if a.allocating == nil {
+		return "make(" + kind + ", " + size + ")"
+	}
 
-type methoder interface {
-	types.Type
-	Method(i int) *types.Func
-	NumMethods() int
+	param := "len int"
+	if method == "MakeMap" {
+		param = "size int"
+	}
+
+	return a.allocatorHook("Make"+hookName(kind), param, kind, size)
 }
 
-func locateType(x, sel string, p *packages.Package) (object, error) {
-	for _, t := range p.TypesInfo.Defs {
-		if t == nil {
-			continue
+// copyElemsInto deep copies the pointers of the source slice into the values
+// pointed to by those of the sink, reusing them, or allocating the missing ones.
+// Those with a DeepCopyInto method are copied with it.
+func (a *app) copyElemsInto(source, sink, idx, x string, p *types.Pointer, w io.Writer, imports map[string]string, skips skips, generating []object, depth int) {
+	esource, esink := index(source, idx), index(sink, idx)
+	fmt.Fprintf(w, `for %s := range %s {
+	if %s == nil {
+		%s = nil
+		continue
+	}
+	if %s == nil {
+		%s = %s
+	}
+`, idx, source, esource, esink, esink, esink, a.newExpr(a.getElemType(p.Elem(), x, imports), imports))
+
+	if e, ok := types.Unalias(p.Elem()).(methoder); ok && a.hasDeepCopyInto(e, generating) {
+		if obj := generatingType(e, generating); obj != nil && a.funcs {
+			fmt.Fprintf(w, "%s(%s, %s)\n", a.funcName(obj, "DeepCopyInto"), esource, esink)
+		} else {
+			fmt.Fprintf(w, "%s.DeepCopyInto(%s)\n", esource, esink)
 		}
-		m := exprFilter(t.Type(), sel, x)
-		if m == nil {
-			continue
+	} else {
+		if containsLock(p.Elem()) {
+			a.assignFields(w, esource, esink, p.Elem())
+		} else {
+			fmt.Fprintf(w, "*%s = *%s\n", esink, esource)
 		}
 
-		return m, nil
+		if _, ok := p.Elem().Underlying().(*types.Struct); !ok {
+			esource, esink = deref(esource), deref(esink)
+		}
+		a.walkType(esource, esink, x, p.Elem(), w, imports, skips, generating, depth)
 	}
 
-	return nil, errors.New("type not found")
+	fmt.Fprintf(w, "}\n")
 }
 
-func reducePointer(typ types.Type) (types.Type, bool) {
-	if pointer, ok := typ.(pointer); ok {
-		return pointer.Elem(), true
+// dynamicCopy copies the value of the interface with the method of its dynamic
+// type, looked up with reflect, if it returns a value of the interface. The
+// value is shared otherwise, as are nil pointers.
+func (a *app) dynamicCopy(source, sink, x string, t types.Type, w io.Writer, imports map[string]string) {
+	imports["reflect"] = "reflect"
+
+	fmt.Fprintf(w, `if %s != nil {
+	if rv := reflect.ValueOf(%s); rv.Kind() != reflect.Pointer || !rv.IsNil() {
+		if rm := rv.MethodByName(%q); rm.IsValid() && rm.Type().NumIn() == 0 && rm.Type().NumOut() == 1 {
+			if rc, ok := rm.Call(nil)[0].Interface().(%s); ok {
+				%s = rc
+			}
+		}
 	}
-	return typ, false
+}
+`, source, source, a.ifaceCopyMethod(), a.getElemType(t, x, imports), sink)
 }
 
-func objFromType(typ types.Type) object {
-	typ, _ = reducePointer(typ)
-
-	m, ok := typ.(object)
+// recursiveType reports whether the type is one of those being inlined, and
+// returns it if a method can be generated for it, as for the types of the
+// -type flags. It is then recorded as implied by the generated ones.
+func (a *app) recursiveType(t types.Type) (object, bool) {
+	named, ok := t.(*types.Named)
 	if !ok {
-		return nil
+		return nil, false
 	}
 
-	return m
+	var recursive bool
+	for _, expanding := range a.expanding {
+		if types.Identical(expanding, named) {
+			recursive = true
+		}
+	}
+	if !recursive {
+		return nil, false
+	}
+
+	// Methods can only be generated for the types of the package, and
+	// functions of another package only for its exported ones.
+	obj := named.Origin()
+	if obj.Obj().Pkg() != a.current.Obj().Pkg() || (a.outPackage != "" && !obj.Obj().Exported()) {
+		return nil, true
+	}
+
+	a.imply(obj)
+
+	return obj, true
 }
 
-func exprFilter(t types.Type, sel string, x string) object {
-	m := objFromType(t)
-	if m == nil {
+// staleType returns the type if it's one of the package whose DeepCopy method
+// is declared in the output file to be overwritten, so that the method is
+// generated again instead of being lost, as an implied type.
+func (a *app) staleType(t types.Type) object {
+	named, ok := t.(*types.Named)
+	if !ok || a.output == "" || a.fset == nil {
 		return nil
 	}
 
-	obj := m.Obj()
-	if obj.Pkg() == nil || x != obj.Pkg().Name() || sel != obj.Name() {
+	obj := named.Origin()
+	if obj.Obj().Pkg() != a.current.Obj().Pkg() {
 		return nil
 	}
 
-	return m
-}
-
-func (a *app) walkType(source, sink, x string, m types.Type, w io.Writer, imports map[string]string, skips skips, generating []object, depth int) {
-	initial := depth == 0
-	if m == nil {
-		return
+	sel := types.NewMethodSet(types.NewPointer(obj)).Lookup(obj.Obj().Pkg(), a.copyMethod())
+	if sel == nil || len(sel.Index()) > 1 {
+		return nil
 	}
 
-	if a.maxDepth > 0 {
-		sinkDepth := strings.Count(sink, ".")
-		if sinkDepth >= a.maxDepth {
-			p := strings.Split(sink, ".")
-			stoppedAt := strings.TrimSuffix(fmt.Sprintf("%s.%s", generating[0], strings.Join(p[1:len(p)-1], ".")), ".")
-			log.Printf("WARNING: reached max depth %d. stop recursion at %s", sinkDepth, stoppedAt)
-			return
-		}
+	output, err := filepath.Abs(a.output)
+	if err != nil || a.fset.Position(sel.Obj().Pos()).Filename != output {
+		return nil
 	}
 
-	var needExported bool
-	switch v := m.(type) {
-	case *types.Named:
-		if v.Obj().Pkg() != nil && v.Obj().Pkg().Name() != x {
-			needExported = true
-		}
-	}
+	a.imply(obj)
 
-	if v, ok := m.(methoder); ok && !initial && a.reuseDeepCopy(source, sink, v, false, generating, w) {
-		return
+	return obj
+}
+
+// imply records the type as implied by the generated ones.
+func (a *app) imply(obj object) {
+	if generatingType(obj, a.implied) == nil {
+		a.implied = append(a.implied, obj)
 	}
+}
 
-	depth++
-	under := m.Underlying()
-	switch v := under.(type) {
-	case *types.Struct:
-		for i := 0; i < v.NumFields(); i++ {
-			field := v.Field(i)
-			if needExported && !field.Exported() {
-				continue
-			}
-			fname := field.Name()
-			sel := sink + "." + fname
-			sel = sel[strings.Index(sel, ".")+1:]
-			if _, ok := skips[sel]; ok {
-				continue
-			}
-			a.walkType(source+"."+fname, sink+"."+fname, x, field.Type(), w, imports, skips, generating, depth)
-		}
-	case *types.Slice:
-		kind := getElemType(v.Elem(), x, imports)
+// hasDeepCopyInto reports whether the type has a DeepCopyInto(out *T) method,
+// either generated, or declared.
+func (a *app) hasDeepCopyInto(v methoder, generating []object) bool {
+	if generatingType(v, generating) != nil {
+		return a.into
+	}
 
-		idx := "i"
-		if depth > 1 {
-			idx += strconv.Itoa(depth)
+	for i := 0; i < v.NumMethods(); i++ {
+		m := v.Method(i)
+		if m.Name() != "DeepCopyInto" {
+			continue
 		}
 
-		// sel is only used for skips
-		sel := "[i]"
-		sel = sel[strings.Index(sel, ".")+1:]
-		if !initial {
-			sel = sink + sel
+		sig, ok := m.Type().(*types.Signature)
+		if !ok || sig.Params().Len() != 1 || sig.Results().Len() != 0 {
+			return false
 		}
 
-		var skipSlice bool
-		if skips.Contains(sel) {
-			skipSlice = true
-		}
+		recv, _ := reducePointer(sig.Recv().Type())
+		out, ok := sig.Params().At(0).Type().(*types.Pointer)
 
-		fmt.Fprintf(w, `if %s != nil {
-	%s = make([]%s, len(%s))
-`, source, sink, kind, source)
+		return ok && types.Identical(out.Elem(), recv)
+	}
 
-		fmt.Fprintf(w, `copy(%s, %s)
-`, sink, source)
+	return false
+}
 
-		var b bytes.Buffer
+// isReusable reports whether the sink is a slice of out, or one of the fields of
+// its struct values, whose backing array is still that of the destination of
+// DeepCopyInto. Slices behind pointers, or in the elements of slices and maps
+// are already replaced by the time they are copied.
+func (a *app) isReusable(sink string) bool {
+	if !a.into || (sink != "*out" && sink != "out" && !strings.HasPrefix(sink, "out.")) {
+		return false
+	}
 
-		if !skipSlice {
-			baseSel := "[" + idx + "]"
-			a.walkType(source+baseSel, sink+baseSel, x, v.Elem(), &b, imports, skips, generating, depth)
+	t := a.current.Obj().Type().Underlying()
+	for _, name := range strings.Split(sink, ".")[1:] {
+		v, ok := t.(*types.Struct)
+		if !ok {
+			return false
 		}
 
-		if b.Len() > 0 {
-			fmt.Fprintf(w, `    for %s := range %s {
-`, idx, source)
+		var found bool
+		for i := 0; i < v.NumFields(); i++ {
+			if field := v.Field(i); field.Name() == name {
+				t, found = field.Type().Underlying(), true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
 
-			b.WriteTo(w)
+	_, ok := t.(*types.Slice)
 
-			fmt.Fprintf(w, "}\n")
-		}
+	return ok
+}
 
-		fmt.Fprintf(w, "}\n")
-	case *types.Pointer:
-		fmt.Fprintf(w, "if %s != nil {\n", source)
+// prevIdent returns the name of the variable keeping the slice of out at the
+// sink, before out is overwritten.
+func prevIdent(sink string) string {
+	return "prev" + strings.TrimPrefix(selToIdent(sink), "out")
+}
 
-		if e, ok := v.Elem().(methoder); !ok || initial || !a.reuseDeepCopy(source, sink, e, true, generating, w) {
-			kind := getElemType(v.Elem(), x, imports)
+// makeSliceExpr returns the expression making the copy of the source slice, of
+// the type kind, of its length, and of its capacity too if preserved.
+func (a *app) makeSliceExpr(kind, source string, imports map[string]string) string {
+	length := "len(" + source + ")"
+	if !a.preserveCap {
+		return a.makeExpr("MakeSlice", kind, length, imports)
+	}
 
-			fmt.Fprintf(w, `%s = new(%s)
-	*%s = *%s
-`, sink, kind, sink, source)
+	capacity := "cap(" + source + ")"
+	if a.allocating == nil {
+		return "make(" + kind + ", " + length + ", " + capacity + ")"
+	}
 
-			a.walkType(source, sink, x, v.Elem(), w, imports, skips, generating, depth)
-		}
+	// Allocators make slices as long as their capacity, which are then
+	// resliced to the length of the source.
+	return a.makeExpr("MakeSlice", kind, capacity, imports) + "[:" + length + "]"
+}
 
-		fmt.Fprintf(w, "}\n")
-	case *types.Chan:
-		kind := getElemType(v.Elem(), x, imports)
+// allocatorHook returns the call of the named hook of the allocator, recording
+// its declaration, with the parameter and result, in its interface.
+func (a *app) allocatorHook(name, param, result, arg string) string {
+	a.allocating.hooks[name] = name + "(" + param + ") " + result
 
-		fmt.Fprintf(w, `if %s != nil {
-	%s = make(chan %s, cap(%s))
+	return "allocator." + name + "(" + arg + ")"
 }
-`, source, sink, kind, source)
-	case *types.Map:
-		kkind := getElemType(v.Key(), x, imports)
-		vkind := getElemType(v.Elem(), x, imports)
 
-		key, val := "k", "v"
+// allocatorType returns the allocator interface of the DeepCopyWith method of
+// the type kind, such as TreeAllocator[K] for Tree[K].
+func allocatorType(kind string) string {
+	if i := strings.IndexByte(kind, '['); i >= 0 {
+		return kind[:i] + "Allocator" + kind[i:]
+	}
 
-		if depth > 1 {
-			key += strconv.Itoa(depth)
-			val += strconv.Itoa(depth)
-		}
+	return kind + "Allocator"
+}
 
-		// Sel is only used for skips
-		sel := "[k]"
-		if !initial {
-			sel = sink + sel
+// allocatorInterface returns the declaration of the allocator interface of the
+// DeepCopyWith method of the allocation: its hooks, and those of the generated
+// types it copies, whose interfaces are embedded instead when generic.
+func (a *app) allocatorInterface(alloc *allocation, x string, imports map[string]string) []byte {
+	hooks := map[string]string{}
+	embedded := map[string]bool{}
+	seen := map[object]bool{}
+
+	var add func(alloc *allocation)
+	add = func(alloc *allocation) {
+		seen[alloc.obj] = true
+		for name, hook := range alloc.hooks {
+			hooks[name] = hook
 		}
-		sel = sel[strings.Index(sel, ".")+1:]
 
-		var skipKey, skipValue bool
-		if skips.Contains(sel) {
-			skipKey, skipValue = true, true
+		for _, callee := range alloc.callees {
+			named, ok := callee.obj.(*types.Named)
+			switch {
+			case seen[callee.obj]:
+			case ok && named.TypeParams().Len() > 0:
+				embedded[allocatorType(callee.kind)] = true
+			default:
+				for _, other := range a.allocations {
+					if other.obj == callee.obj {
+						add(other)
+					}
+				}
+			}
 		}
+	}
+	add(alloc)
 
-		fmt.Fprintf(w, `if %s != nil {
-	%s = make(map[%s]%s, len(%s))
-	for %s, %s := range %s {
-`, source, sink, kkind, vkind, source, key, val, source)
-
-		ksink, vsink := key, val
+	var buf bytes.Buffer
+	name := alloc.obj.Obj().Name() + "Allocator"
+	fmt.Fprintf(&buf, `// %s allocates the values copied by %s.DeepCopyWith
+type %s%s interface {
+`, name, alloc.obj.Obj().Name(), name, a.typeParams(alloc.obj, x, imports))
+	lines := make([]string, 0, len(embedded)+len(hooks))
+	for kind := range embedded {
+		lines = append(lines, kind)
+	}
+	sort.Strings(lines)
+	for _, hook := range hooks {
+		lines = append(lines, hook)
+	}
+	sort.Strings(lines[len(embedded):])
+	for _, line := range lines {
+		fmt.Fprintf(&buf, "%s\n", line)
+	}
+	buf.WriteString("}")
 
-		var b bytes.Buffer
+	return buf.Bytes()
+}
 
-		if !skipKey {
-			copyKSink := selToIdent(sink) + "_" + key
-			a.walkType(key, copyKSink, x, v.Key(), &b, imports, skips, generating, depth)
+// hookName returns the name of the type kind in those of the allocator hooks,
+// such as StringToIntSliceMap for map[string][]int.
+func hookName(kind string) string {
+	switch {
+	case strings.HasPrefix(kind, "*"):
+		return hookName(kind[1:]) + "Ptr"
+	case strings.HasPrefix(kind, "[]"):
+		return hookName(kind[2:]) + "Slice"
+	case strings.HasPrefix(kind, "["):
+		i := strings.IndexByte(kind, ']')
+		return hookName(kind[i+1:]) + "Array" + kind[1:i]
+	case strings.HasPrefix(kind, "map["):
+		i := closingBracket(kind, len("map"))
+		return hookName(kind[len("map["):i]) + "To" + hookName(kind[i+1:]) + "Map"
+	case strings.HasPrefix(kind, "chan "):
+		return hookName(kind[len("chan "):]) + "Chan"
+	}
 
-			if b.Len() > 0 {
-				ksink = copyKSink
-				fmt.Fprintf(w, "var %s %s\n", ksink, kkind)
-				b.WriteTo(w)
-			}
+	// The type arguments of generic types follow their name.
+	if i := strings.IndexByte(kind, '['); i > 0 && !strings.ContainsAny(kind[:i], "{(") {
+		var args []string
+		for _, arg := range splitTypeList(kind[i+1 : closingBracket(kind, i)]) {
+			args = append(args, hookName(arg))
 		}
+		return hookName(kind[:i]) + "Of" + strings.Join(args, "And")
+	}
 
-		b.Reset()
+	// Qualified and literal types are named by the words they are made of.
+	var b strings.Builder
+	upper := true
+	for _, r := range kind {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
 
-		if !skipValue {
-			copyVSink := selToIdent(sink) + "_" + val
-			a.walkType(val, copyVSink, x, v.Elem(), &b, imports, skips, generating, depth)
+	return b.String()
+}
 
-			if b.Len() > 0 {
-				vsink = copyVSink
-				fmt.Fprintf(w, "var %s %s\n", vsink, vkind)
-				b.WriteTo(w)
+// closingBracket returns the index of the bracket closing the one at index i of
+// the type kind.
+func closingBracket(kind string, i int) int {
+	depth := 0
+	for j := i; j < len(kind); j++ {
+		switch kind[j] {
+		case '[', '{', '(':
+			depth++
+		case ']', '}', ')':
+			depth--
+			if depth == 0 {
+				return j
 			}
 		}
-
-		fmt.Fprintf(w, "%s[%s] = %s", sink, ksink, vsink)
-
-		fmt.Fprintf(w, "}\n}\n")
 	}
 
+	return len(kind) - 1
 }
 
-func getElemType(t types.Type, x string, imports map[string]string) string {
-	kind := types.TypeString(t, func(p *types.Package) string {
-		name := p.Name()
-		if name != x {
-			if path, ok := imports[name]; ok && path != p.Path() {
-				name = strings.ReplaceAll(p.Path(), "/", "_")
+// splitTypeList splits the comma separated list of types, such as type
+// arguments, leaving those nested in other types whole.
+func splitTypeList(list string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, c := range list {
+		switch c {
+		case '[', '{', '(':
+			depth++
+		case ']', '}', ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(list[start:i]))
+				start = i + 1
 			}
-			imports[name] = p.Path()
-			return name
 		}
+	}
+
+	return append(parts, strings.TrimSpace(list[start:]))
+}
+
+// fieldTag returns the value of the configured tag of the struct's field i.
+func (a *app) fieldTag(v *types.Struct, i int) string {
+	if a.tag == "" {
 		return ""
-	})
+	}
 
-	return kind
+	return reflect.StructTag(v.Tag(i)).Get(a.tag)
 }
 
-func (a *app) hasDeepCopy(v methoder, generating []object) (hasMethod, isPointer bool) {
-	for _, t := range generating {
-		if types.Identical(v, t) {
-			return true, a.isPtrRecv
-		}
+// zeroValue returns the expression of the zero value of the type.
+func (a *app) zeroValue(t types.Type, x string, imports map[string]string) string {
+	if _, ok := t.(*types.TypeParam); ok {
+		return "*new(" + a.getElemType(t, x, imports) + ")"
 	}
 
-	for i := 0; i < v.NumMethods(); i++ {
-		m := v.Method(i)
-		if m.Name() != "DeepCopy" {
-			continue
+	switch v := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case v.Info()&types.IsBoolean != 0:
+			return "false"
+		case v.Info()&types.IsString != 0:
+			return `""`
+		case v.Kind() == types.UnsafePointer:
+			return "nil"
+		default:
+			return "0"
 		}
+	case *types.Struct, *types.Array:
+		return a.getElemType(t, x, imports) + "{}"
+	default:
+		return "nil"
+	}
+}
 
-		sig, ok := m.Type().(*types.Signature)
-		if !ok {
+// implsOf returns the implementations given for the interface type.
+func (a *app) implsOf(t types.Type) []types.Type {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return nil
+	}
+
+	return a.impls[named.Obj()]
+}
+
+// copyImpls copies an interface value with a type switch over its given
+// implementations. Values of any other type are shared.
+func (a *app) copyImpls(source, sink, x string, impls []types.Type, w io.Writer, imports map[string]string, skips skips, generating []object, depth int) {
+	impl := "impl" + strconv.Itoa(depth)
+	copySink := selToIdent(sink) + "_" + impl
+
+	var cases bytes.Buffer
+	for _, t := range impls {
+		var b bytes.Buffer
+		a.walkType(impl, copySink, x, t, &b, imports, skips, generating, depth)
+		if b.Len() == 0 {
 			continue
 		}
 
-		if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+		kind := a.getElemType(t, x, imports)
+		fmt.Fprintf(&cases, "case %s:\n", kind)
+		a.declareCopy(&cases, copySink, kind, impl, t, b.Bytes())
+		b.WriteTo(&cases)
+		fmt.Fprintf(&cases, "%s = %s\n", sink, copySink)
+	}
+
+	if cases.Len() == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "switch %s := %s {\n", impl, selectField(source, "(type)"))
+	cases.WriteTo(w)
+	fmt.Fprintf(w, "}\n")
+}
+
+// interfaceDeepCopy reports whether the method set of the interface, including
+// the methods of embedded interfaces, has a "name() T" method, such as
+// DeepCopy, where T is either assignable to typ, or an interface the result has
+// to be asserted from.
+func interfaceDeepCopy(typ types.Type, iface *types.Interface, name string) (found, assert bool) {
+	for i := 0; i < iface.NumMethods(); i++ {
+		m := iface.Method(i)
+		if m.Name() != name {
 			continue
 		}
 
-		ret := sig.Results().At(0)
-		retType, retPointer := reducePointer(ret.Type())
-		sigType, _ := reducePointer(sig.Recv().Type())
-
-		if !types.Identical(retType, sigType) {
+		sig, ok := m.Type().(*types.Signature)
+		if !ok || sig.Params().Len() != 0 || sig.Results().Len() != 1 {
 			return false, false
 		}
 
-		return true, retPointer
+		ret := sig.Results().At(0).Type()
+		if types.AssignableTo(ret, typ) {
+			return true, false
+		}
+
+		return types.IsInterface(ret), true
 	}
 
 	return false, false
 }
 
-func (a *app) reuseDeepCopy(source, sink string, v methoder, pointer bool, generating []object, w io.Writer) bool {
+func (a *app) reuseDeepCopy(source, sink, x string, v methoder, pointer bool, imports map[string]string, generating []object, w io.Writer) bool {
+	// DeepCopyInto methods copy the members with one into their destination,
+	// sparing the allocation of the copy. Values reusing the slices of out
+	// can't, as out then holds those of the source.
+	if a.into && a.hasDeepCopyInto(v, generating) && (pointer || !a.reuse) {
+		into := func(source, out string) string {
+			if obj := generatingType(v, generating); obj != nil && a.funcs {
+				if !pointer {
+					source = "&" + source
+				}
+				return a.funcName(obj, "DeepCopyInto") + "(" + source + ", " + out + ")"
+			}
+			return selectField(source, "DeepCopyInto("+out+")")
+		}
+
+		if pointer {
+			fmt.Fprintf(w, `%s = %s
+	%s
+`, sink, a.newExpr(a.getElemType(v, x, imports), imports), into(source, sink))
+		} else {
+			fmt.Fprintf(w, "%s\n", into(source, "&"+sink))
+		}
+		return true
+	}
+
 	hasMethod, isPointer := a.hasDeepCopy(v, generating)
 
+	call := func(source string) string {
+		return selectField(source, a.copyMethod()+"()")
+	}
+	obj := generatingType(v, generating)
+	switch {
+	case obj != nil && a.allocating != nil:
+		a.allocating.callees = append(a.allocating.callees, allocCallee{obj: obj, kind: a.getElemType(v, x, imports)})
+		call = func(source string) string {
+			return selectField(source, "DeepCopyWith(allocator)")
+		}
+	case obj != nil && a.funcs:
+		// The generated function takes the receiver kind as is.
+		call = func(source string) string {
+			if pointer && !isPointer {
+				source = deref(source)
+			} else if !pointer && isPointer {
+				source = "&" + source
+			}
+			return a.funcName(obj, a.copyMethod()) + "(" + source + ")"
+		}
+	}
+
 	if hasMethod {
 		if pointer == isPointer {
-			fmt.Fprintf(w, "%s = %s.DeepCopy()\n", sink, source)
+			fmt.Fprintf(w, "%s = %s\n", sink, call(source))
+		} else if pointer && obj != nil && a.allocating != nil {
+			fmt.Fprintf(w, `%s = %s
+	*%s = %s
+`, sink, a.newExpr(a.getElemType(v, x, imports), imports), sink, call(source))
 		} else if pointer {
-			fmt.Fprintf(w, `retV := %s.DeepCopy()
+			fmt.Fprintf(w, `retV := %s
 	%s = &retV
-`, source, sink)
+`, call(source), sink)
 		} else {
-			fmt.Fprintf(w, `{
-	retV := %s.DeepCopy()
+			// The method may return nil, even if the receiver isn't.
+			fmt.Fprintf(w, `if retV := %s; retV != nil {
 	%s = *retV
 }
-`, source, sink)
+`, call(source), sink)
 		}
 	}
 
 	return hasMethod
 }
 
+func deref(sel string) string {
+	return "*" + sel
+}
+
+// index returns the expression indexing sel with idx, parenthesizing sel if it
+// is a dereference, so that the index applies to the pointed-to value.
+func index(sel, idx string) string {
+	if strings.HasPrefix(sel, "*") {
+		sel = "(" + sel + ")"
+	}
+
+	return sel + "[" + idx + "]"
+}
+
+// selectField returns the expression selecting the field name of sel,
+// parenthesizing sel if it is a dereference.
+func selectField(sel, name string) string {
+	if strings.HasPrefix(sel, "*") {
+		sel = "(" + sel + ")"
+	}
+
+	return sel + "." + name
+}
+
 func selToIdent(sel string) string {
-	sel = strings.ReplaceAll(sel, "]", "")
+	sel = strings.NewReplacer("]", "", "(", "", ")", "", "*", "").Replace(sel)
 
 	return strings.Map(func(r rune) rune {
 		switch r {
@@ -2,25 +2,78 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/globusdigital/deep-copy/testdata"
+	"github.com/globusdigital/deep-copy/testdata/all"
+	"github.com/globusdigital/deep-copy/testdata/containers"
+	"github.com/globusdigital/deep-copy/testdata/copies"
+	"github.com/globusdigital/deep-copy/testdata/handlers"
+	"github.com/globusdigital/deep-copy/testdata/handlers/loggers"
+	"github.com/globusdigital/deep-copy/testdata/remote/keypkg"
+	legacy "github.com/globusdigital/deep-copy/testdata/remote/legacy/valpkg"
+	"github.com/globusdigital/deep-copy/testdata/remote/valpkg"
 )
 
 func Test_run(t *testing.T) {
 	tests := []struct {
-		name     string
-		types    typesVal
-		path     string
-		pointer  bool
-		skips    skipsVal
-		maxdepth int
-		want     []byte
+		name            string
+		types           typesVal
+		path            string
+		pointer         bool
+		skips           skipsVal
+		maxdepth        int
+		into            bool
+		reuse           bool
+		skipAll         []string
+		deep            []string
+		skipTypes       []string
+		tests           bool
+		shallow         bool
+		genEqual        bool
+		genSlice        bool
+		explain         bool
+		runtimeOpts     bool
+		optional        []string
+		strict          bool
+		allocator       bool
+		output          string
+		nilMapAsEmpty   bool
+		nilSliceAsEmpty bool
+		preserveCap     bool
+		aliasing        bool
+		all             bool
+		funcs           bool
+		outPackage      string
+		ifaceImpls      map[string][]string
+		method          string
+		ifaceMethod     string
+		ifaceDynamic    bool
+		importAliases   map[string]string
+		dropChan        bool
+		deepKeys        bool
+		tag             string
+		license         string
+		want            []byte
+		warnings        []string
 	}{
 		{name: "foo", types: typesVal{"Foo"}, path: "./testdata", want: []byte(FooFile)},
 		{name: "foo - pointer", types: typesVal{"Foo"}, pointer: true, path: "./testdata", want: []byte(FooPointerFile)},
 		{name: "foo - pointer, skip slice", types: typesVal{"Foo"}, pointer: true, skips: skipsVal{{"Slice": struct{}{}}}, path: "./testdata", want: []byte(FooPointerSkipSliceFile)},
+		{name: "foo - pointer, skip slice through the map", types: typesVal{"Foo"}, pointer: true, skips: skipsVal{{"Map[k].Slice": struct{}{}}}, path: "./testdata", want: []byte(FooPointerSkipSliceFile)},
 		{name: "foo, skip map member", types: typesVal{"Foo"}, skips: skipsVal{{"Map[k]": struct{}{}}}, path: "./testdata", want: []byte(FooSkipMapFile)},
 		{name: "alpha - with DeepCopy method", types: typesVal{"Alpha"}, path: "./testdata", want: []byte(AlphaPointer)},
 		{name: "slicepointer, skip slice member", types: typesVal{"SlicePointer"}, skips: skipsVal{{"[i]": struct{}{}}}, path: "./testdata", want: []byte(SlicePointer)},
@@ -38,13 +91,178 @@ func Test_run(t *testing.T) {
 		{name: "issue 15, parent has child pointer, value receiver", types: typesVal{"ParentHasChildPointer", "Child"}, path: "./testdata", want: []byte(I15ParentHasChildPointerValueRecv)},
 		{name: "issue 15, parent has child value, pointer receiver", pointer: true, types: typesVal{"ParentHasChildValue", "Child"}, path: "./testdata", want: []byte(I15ParentHasChildValuePointerRecv)},
 		{name: "issue 15, parent has child pointer, pointer receiver", pointer: true, types: typesVal{"ParentHasChildPointer", "Child"}, path: "./testdata", want: []byte(I15ParentHasChildPointerPointerRecv)},
-		{name: "issue 17, with maxdepth", types: typesVal{"Depth1"}, pointer: true, maxdepth: 2, path: "./testdata", want: []byte(Issue17MaxDepth)},
+		{name: "issue 17, with maxdepth", types: typesVal{"Depth1"}, pointer: true, maxdepth: 2, path: "./testdata", want: []byte(Issue17MaxDepth), warnings: []string{
+			"reached max depth 2. stop recursion at github.com/globusdigital/deep-copy/testdata.Depth1.a1",
+			"reached max depth 2. stop recursion at github.com/globusdigital/deep-copy/testdata.Depth1.a2",
+		}},
+		{name: "into, named slice", types: typesVal{"SlicePointer"}, into: true, path: "./testdata", want: []byte(IntoSlicePointer)},
+		{name: "into, named map, pointer", types: typesVal{"MapPointer"}, into: true, pointer: true, path: "./testdata", want: []byte(IntoMapPointer)},
+		{name: "slicepointer, pointer receiver", types: typesVal{"SlicePointer"}, pointer: true, path: "./testdata", want: []byte(SlicePointerPointerRecv)},
+		{name: "skip all, shared field name", types: typesVal{"Service", "Worker"}, skips: skipsVal{{"Deps": struct{}{}}}, skipAll: []string{"logger", "*.logger"}, path: "./testdata", want: []byte(SkipAllLogger)},
+		{name: "type defined in a test file", types: typesVal{"Fixture"}, tests: true, path: "./testdata", want: []byte(TestsFixture)},
+		{name: "interface with embedded DeepCopy method", types: typesVal{"Drawing"}, path: "./testdata", want: []byte(InterfaceEmbeddedDeepCopy)},
+		{name: "gen shallow, skipping declared method", types: typesVal{"Shallow", "ShallowWithMethod"}, shallow: true, path: "./testdata", want: []byte(GenShallow), warnings: []string{"ShallowWithMethod already has a ShallowCopy method, skipping it"}},
+		{name: "gen shallow, pointer receiver", types: typesVal{"Shallow"}, shallow: true, pointer: true, path: "./testdata", want: []byte(GenShallowPointer)},
+		{name: "gen shallow, method declared in output", types: typesVal{"ShallowWithMethod"}, shallow: true, output: "./testdata/shallow.go", path: "./testdata", want: []byte(GenShallowOutput)},
+		{name: "slice of pointers with nil elements", types: typesVal{"PtrList"}, path: "./testdata", want: []byte(PtrListNilElements)},
+		{name: "slice of interfaces without DeepCopy", types: typesVal{"Readers"}, path: "./testdata", want: []byte(InterfaceSliceShared), warnings: []string{"Readers.Sources[i] is of interface type io.Reader without a DeepCopy method, its value is shared"}},
+		{name: "map with channel values", types: typesVal{"ChanMap"}, path: "./testdata", want: []byte(ChanMapValues)},
+		{name: "map with channel values, strict", types: typesVal{"ChanMap"}, strict: true, path: "./testdata", want: []byte(ChanMapValues)},
+		{name: "channels of reference types", types: typesVal{"RefChans"}, path: "./testdata", want: []byte(RefChansFile), warnings: []string{
+			"RefChans.Batches is a channel of reference type []int, its buffered values aren't copied",
+			"RefChans.Bars[k] is a channel of reference type *testdata.Bar, its buffered values aren't copied",
+		}},
+		{name: "pointers to channels", types: typesVal{"ChanPointers"}, path: "./testdata", want: []byte(ChanPointersFile)},
+		{name: "pointers to channels, dropped", types: typesVal{"ChanPointers"}, dropChan: true, path: "./testdata", want: []byte(ChanPointersDropFile)},
+		{name: "channels of reference types, dropped", types: typesVal{"RefChans"}, dropChan: true, path: "./testdata", want: []byte(RefChansDropFile)},
+		{name: "nil-ness of every kind", types: typesVal{"NilKinds"}, path: "./testdata", want: []byte(NilKindsFile)},
+		{name: "nested generic instantiations from another package", types: typesVal{"NestedGeneric"}, path: "./testdata", want: []byte(NestedGenerics)},
+		{name: "nil map as empty", types: typesVal{"NilAsEmpty"}, nilMapAsEmpty: true, path: "./testdata", want: []byte(NilMapAsEmpty)},
+		{name: "nil slice as empty", types: typesVal{"NilAsEmpty"}, nilSliceAsEmpty: true, path: "./testdata", want: []byte(NilSliceAsEmpty)},
+		{name: "generic set", types: typesVal{"Set"}, path: "./testdata", want: []byte(GenericSet)},
+		{name: "generic set, into, pointer", types: typesVal{"Set"}, into: true, pointer: true, path: "./testdata", want: []byte(GenericSetIntoPointer)},
+		{name: "nested anonymous structs with cross-package slices", types: typesVal{"AnonymousNested"}, path: "./testdata", want: []byte(AnonymousNestedFile)},
+		{name: "pointers to slices and maps of pointers", types: typesVal{"PtrSlice", "PtrPtr"}, path: "./testdata", want: []byte(PtrSliceFile)},
+		{name: "functions in another package", types: typesVal{"Tree", "Node"}, funcs: true, outPackage: "copies", path: "./testdata", want: []byte(FuncsOutPackage)},
+		{name: "functions, into, pointer, gen shallow", types: typesVal{"Tree", "Node", "Set"}, funcs: true, into: true, pointer: true, shallow: true, path: "./testdata", want: []byte(FuncsIntoPointer)},
+		{name: "interface implementations in map of slices", types: typesVal{"EventLog"}, ifaceImpls: map[string][]string{"Event": {"ClickEvent", "*KeyEvent", "PlainEvent"}}, path: "./testdata", want: []byte(EventLogImpls)},
+		{name: "named array fields", types: typesVal{"Grids"}, path: "./testdata", want: []byte(GridsFile)},
+		{name: "named array fields, reused", types: typesVal{"Grids", "Ptrs"}, path: "./testdata", want: []byte(GridsPtrsFile)},
+		{name: "error interfaces, strict", types: typesVal{"Failure"}, strict: true, path: "./testdata", want: []byte(FailureFile)},
+		{name: "error interfaces, implementations", types: typesVal{"Failure"}, strict: true, ifaceImpls: map[string][]string{"AppError": {"*CodeError"}}, path: "./testdata", want: []byte(FailureImplsFile)},
+		{name: "pointer to map of slices of pointers", types: typesVal{"BarIndex"}, path: "./testdata", want: []byte(BarIndexFile)},
+		{name: "named interface types", types: typesVal{"Routes"}, path: "./testdata", want: []byte(RoutesFile), warnings: []string{
+			"Routes.Default is of interface type testdata.NamedHandler without a DeepCopy method, its value is shared",
+			"Routes.ByPath[k] is of interface type testdata.NamedHandler without a DeepCopy method, its value is shared",
+		}},
+		{name: "named interface types, implementations", types: typesVal{"Routes"}, ifaceImpls: map[string][]string{"NamedHandler": {"github.com/globusdigital/deep-copy/testdata/handlers.Mux"}}, path: "./testdata", want: []byte(RoutesImplsFile)},
+		{name: "interface implementations in array", types: typesVal{"EventRing"}, ifaceImpls: map[string][]string{"Event": {"ClickEvent", "*KeyEvent", "PlainEvent"}}, path: "./testdata", want: []byte(EventRingImpls)},
+		{name: "unexported fields of another package are shallow copied", types: typesVal{"ExternalUnexported"}, path: "./testdata", want: []byte(ExternalUnexportedFile)},
+		{name: "drop chan", types: typesVal{"DropChan"}, dropChan: true, path: "./testdata", want: []byte(DropChanFile)},
+		{name: "keep chan", types: typesVal{"DropChan"}, path: "./testdata", want: []byte(KeepChanFile)},
+		{name: "zero and skip tags", types: typesVal{"Tagged"}, tag: "deepcopy", path: "./testdata", want: []byte(TaggedFile)},
+		{name: "map of arrays of structs with DeepCopy", types: typesVal{"MapArray"}, path: "./testdata", want: []byte(MapArrayFile)},
+		{name: "license header", types: typesVal{"Child"}, license: "// Copyright 2020 Globus\n//\n// Licensed under MIT.\n\n", path: "./testdata", want: []byte(LicensedChild)},
+		{name: "recursive generic type", types: typesVal{"GenericTree"}, path: "./testdata", want: []byte(GenericTreeFile), warnings: []string{"GenericTree.Value is of type parameter T, its value is shared"}},
+		{name: "function fields, strict", types: typesVal{"FuncFields"}, strict: true, path: "./testdata", want: []byte(FuncFieldsFile)},
+		{name: "multi-return and variadic function fields, strict", types: typesVal{"Validators"}, strict: true, path: "./testdata", want: []byte(ValidatorsFile)},
+		{name: "map of function values, strict", types: typesVal{"Callbacks"}, strict: true, path: "./testdata", want: []byte(CallbacksFile)},
+		{name: "named function types, strict", types: typesVal{"Dispatcher"}, strict: true, path: "./testdata", want: []byte(DispatcherFile)},
+		{name: "named function types, other package, strict", types: typesVal{"Dispatcher"}, strict: true, outPackage: "other", funcs: true, path: "./testdata", want: []byte(DispatcherOtherFile)},
+		{name: "interface clone method", types: typesVal{"ObjectList"}, ifaceMethod: "DeepCopyObject", path: "./testdata", want: []byte(ObjectListFile)},
+		{name: "interface clone method, not given", types: typesVal{"ObjectList"}, path: "./testdata", want: []byte(ObjectListSharedFile), warnings: []string{
+			"ObjectList.Items[i] is of interface type testdata.Object without a DeepCopy method, its value is shared",
+			"ObjectList.Owner is of interface type testdata.Object without a DeepCopy method, its value is shared",
+		}},
+		{name: "allocator, pointer receiver", types: typesVal{"Allocated"}, allocator: true, pointer: true, path: "./testdata", want: []byte(AllocatedPointer)},
+		{name: "interface implementations from other packages", types: typesVal{"HandlerChain"}, ifaceImpls: map[string][]string{
+			"github.com/globusdigital/deep-copy/testdata/handlers.Handler": {"github.com/globusdigital/deep-copy/testdata/handlers.Mux", "*github.com/globusdigital/deep-copy/testdata/handlers/loggers.Logger"},
+		}, path: "./testdata", want: []byte(HandlerChainImpls)},
+		{name: "time values in slices and maps", types: typesVal{"Schedule"}, path: "./testdata", want: []byte(ScheduleFile)},
+		{name: "field of a blank imported package", types: typesVal{"Record"}, path: "./testdata", want: []byte(RecordFile)},
+		{name: "map of pointers to a type with a value DeepCopy", types: typesVal{"ItemIndex"}, path: "./testdata", pointer: true, want: []byte(ItemIndexPointerFile)},
+		{name: "struct with locks", types: typesVal{"Counter"}, path: "./testdata", want: []byte(CounterFile), warnings: []string{"Counter contains a lock, generating a pointer receiver"}},
+		{name: "struct with locks into", types: typesVal{"Counter"}, path: "./testdata", into: true, want: []byte(CounterIntoFile), warnings: []string{"Counter contains a lock, generating a pointer receiver"}},
+		{name: "value slice of the type itself with a pointer receiver", types: typesVal{"Kid"}, path: "./testdata", pointer: true, want: []byte(KidPointerFile)},
+		{name: "pinned import alias", types: typesVal{"Record"}, path: "./testdata", importAliases: map[string]string{"github.com/globusdigital/deep-copy/testdata/driver": "dbdriver"}, want: []byte(RecordAliasFile)},
+		{name: "directional channels", types: typesVal{"Directed"}, path: "./testdata", want: []byte(DirectedFile)},
+		{name: "directional channels, dropped", types: typesVal{"Directed"}, path: "./testdata", dropChan: true, want: []byte(DirectedDropFile)},
+		{name: "sql null types", types: typesVal{"Nullable"}, path: "./testdata", want: []byte(NullableFile)},
+		{name: "embedded struct of another package", types: typesVal{"EmbeddedExternal"}, path: "./testdata", want: []byte(EmbeddedExternalFile)},
+		{name: "imported package named as the local one", types: typesVal{"SameName"}, path: "./testdata", want: []byte(SameNameFile)},
+		{name: "pointer to interface with implementations", types: typesVal{"EventRef"}, ifaceImpls: map[string][]string{"Event": {"ClickEvent", "*KeyEvent", "PlainEvent"}}, path: "./testdata", want: []byte(EventRefImpls)},
+		{name: "pointer to interface with DeepCopy", types: typesVal{"ShapeRef"}, path: "./testdata", want: []byte(ShapeRefFile)},
+		{name: "arrays of types with value and pointer DeepCopy, pointer", types: typesVal{"ItemArrays"}, path: "./testdata", pointer: true, want: []byte(ItemArraysPointerFile)},
+		{name: "embedded value type of another package", types: typesVal{"MyTime"}, path: "./testdata", want: []byte(MyTimeFile)},
+		{name: "external generic containers", types: typesVal{"Inventory"}, path: "./testdata", want: []byte(InventoryFile)},
+		{name: "shared map keys", types: typesVal{"Keyed"}, path: "./testdata", want: []byte(KeyedFile)},
+		{name: "deep copied map keys", types: typesVal{"Keyed"}, path: "./testdata", deepKeys: true, want: []byte(KeyedDeepKeysFile)},
+		{name: "array map keys of pointers", types: typesVal{"PairIndex"}, path: "./testdata", want: []byte(PairIndexFile)},
+		{name: "deep copied array map keys of pointers", types: typesVal{"PairIndex"}, path: "./testdata", deepKeys: true, want: []byte(PairIndexDeepKeysFile)},
+		{name: "gen equal", types: typesVal{"Snapshot"}, genEqual: true, output: "./testdata/snapshot_gen.go", path: "./testdata", want: []byte(SnapshotEqualFile), warnings: []string{"Snapshot.Meta is of interface type any without a DeepCopy method, its value is shared"}},
+		{name: "gen equal, pointer receiver", types: typesVal{"Snapshot"}, genEqual: true, pointer: true, output: "./testdata/snapshot_gen.go", path: "./testdata", want: []byte(SnapshotEqualPointerFile), warnings: []string{"Snapshot.Meta is of interface type any without a DeepCopy method, its value is shared"}},
+		{name: "alias chains", types: typesVal{"Audited"}, path: "./testdata", want: []byte(AuditedFile)},
+		{name: "anonymous struct slice elements", types: typesVal{"TaggedItems"}, path: "./testdata", want: []byte(TaggedItemsFile)},
+		{name: "deep copied interface, skipped by pattern", types: typesVal{"EventLog"}, ifaceImpls: map[string][]string{"Event": {"ClickEvent", "*KeyEvent", "PlainEvent"}}, skipAll: []string{"Events", "Last"}, deep: []string{"Last"}, path: "./testdata", want: []byte(EventLogDeepFile)},
+		{name: "deep copied field skipped by tag", types: typesVal{"Tagged"}, tag: "deepcopy", deep: []string{"Shared"}, path: "./testdata", want: []byte(TaggedDeepFile)},
+		{name: "reused DeepCopy declared in another file", types: typesVal{"SplitHolder"}, path: "./testdata", want: []byte(SplitHolderFile)},
+		{name: "preserved slice capacity", types: typesVal{"Buffers"}, preserveCap: true, path: "./testdata", want: []byte(BuffersCapFile)},
+		{name: "preserved slice capacity, allocator", types: typesVal{"Buffers"}, preserveCap: true, allocator: true, path: "./testdata", want: []byte(BuffersCapAllocatorFile)},
+		{name: "generic type instantiated differently, reused", types: typesVal{"Crate", "Crates"}, path: "./testdata", want: []byte(CratesFile), warnings: []string{"Crate.Items[i] is of type parameter T, its value is shared"}},
+		{name: "generic type instantiated differently, inlined", types: typesVal{"Crates"}, path: "./testdata", want: []byte(CratesInlineFile)},
+		{name: "skipped slice field", types: typesVal{"Batch"}, skips: skipsVal{{"Items": struct{}{}}}, path: "./testdata", want: []byte(BatchSkipFieldFile)},
+		{name: "skipped slice elements", types: typesVal{"Batch"}, skips: skipsVal{{"Items[i]": struct{}{}, "Groups[i][i]": struct{}{}}}, path: "./testdata", want: []byte(BatchSkipElementsFile)},
+		{name: "sets of empty structs", types: typesVal{"Sets"}, path: "./testdata", want: []byte(SetsFile)},
+		{name: "generic map values", types: typesVal{"Cache"}, path: "./testdata", want: []byte(CacheFile), warnings: []string{"Cache.Entries[k] is of type parameter V, its value is shared"}},
+		{name: "generic map values, constraint with DeepCopy", types: typesVal{"CopierCache"}, path: "./testdata", want: []byte(CopierCacheFile)},
+		{name: "reused DeepCopy of generic elements", types: typesVal{"Packs"}, path: "./testdata", want: []byte(PacksFile)},
+		{name: "slice helper", types: typesVal{"Book"}, genSlice: true, path: "./testdata", want: []byte(BookSliceFile)},
+		{name: "slice helper, functions, pointer", types: typesVal{"Book"}, genSlice: true, funcs: true, pointer: true, path: "./testdata", want: []byte(BookSliceFuncsFile)},
+		{name: "slice helper, generic", types: typesVal{"GenericTree"}, genSlice: true, path: "./testdata", want: []byte(GenericTreeSliceFile), warnings: []string{"GenericTree.Value is of type parameter T, its value is shared"}},
+		{name: "into, reused slices", types: typesVal{"Frame", "Samples", "Track"}, into: true, reuse: true, pointer: true, path: "./testdata", want: []byte(FramesReuseFile)},
+		{name: "embedded file systems, strict", types: typesVal{"Assets"}, strict: true, path: "./testdata", want: []byte(AssetsFile)},
+		{name: "interface map keys, strict", types: typesVal{"StringerIndex"}, strict: true, path: "./testdata", want: []byte(StringerIndexFile)},
+		{name: "deep copied interface map keys", types: typesVal{"StringerIndex"}, deepKeys: true, path: "./testdata", want: []byte(StringerIndexFile), warnings: []string{"StringerIndex.Bars[k] is of interface type fmt.Stringer without a DeepCopy method, its value is shared"}},
+		{name: "fixed-size arrays", types: typesVal{"FixedArrays", "ArrayNode"}, path: "./testdata", want: []byte(FixedArraysFile)},
+		{name: "dynamic interface copies", types: typesVal{"Sketch"}, ifaceDynamic: true, path: "./testdata", want: []byte(SketchDynamicFile), warnings: []string{"Sketch.Meta is of interface type any without a DeepCopy method, its value is shared"}},
+		{name: "generic container", types: typesVal{"Holder"}, path: "./testdata", want: []byte(HolderFile), warnings: []string{"Holder.Items[i] is of type parameter T, its value is shared", "Holder.Ptr is of type parameter T, its value is shared"}},
+		{name: "generic container, functions", types: typesVal{"Holder"}, funcs: true, path: "./testdata", want: []byte(HolderFuncsFile), warnings: []string{"Holder.Items[i] is of type parameter T, its value is shared", "Holder.Ptr is of type parameter T, its value is shared"}},
+		{name: "into, nested DeepCopyInto", types: typesVal{"Resource"}, into: true, pointer: true, path: "./testdata", want: []byte(ResourceIntoFile)},
+		{name: "recursive types, implied", types: typesVal{"BinaryTree", "Org"}, output: "./testdata/recursive_gen.go", path: "./testdata", want: []byte(RecursiveImpliedFile)},
+		{name: "method name", types: typesVal{"Document", "Snippet"}, method: "Clone", path: "./testdata", want: []byte(DocumentCloneFile)},
+		{name: "skipped types", types: typesVal{"Ledger"}, skipTypes: []string{"github.com/globusdigital/deep-copy/testdata.Symbol"}, path: "./testdata", want: []byte(LedgerSkipTypesFile), warnings: []string{"Ledger contains a lock, generating a pointer receiver"}},
+		{name: "skip, wildcards and nested selectors", types: typesVal{"Stack"}, skips: skipsVal{{"*.Meta": struct{}{}, "Top.Store.Entries": struct{}{}, "Index[k].Store.Hits": struct{}{}}}, path: "./testdata", want: []byte(StackSkipFile)},
+		{name: "preserved aliasing", types: typesVal{"Route"}, aliasing: true, path: "./testdata", want: []byte(RouteAliasingFile)},
+		{name: "all types", all: true, output: "./testdata/all/all_gen.go", path: "./testdata/all", want: []byte(AllFile)},
+		{name: "composite members of other packages", types: typesVal{"Lookup"}, path: "./testdata", want: []byte(LookupFile)},
+		{name: "type patterns", types: typesVal{"Ite*", "Cat.*", "Item"}, output: "./testdata/all/all_gen.go", path: "./testdata/all", want: []byte(TypePatternsFile)},
+		{name: "pointer to pointer with DeepCopy", types: typesVal{"Twice", "Leaf"}, path: "./testdata", want: []byte(TwiceFile)},
+		{name: "pointer to pointer with DeepCopy, allocator", types: typesVal{"Twice", "Leaf"}, allocator: true, path: "./testdata", want: []byte(TwiceAllocatorFile)},
+		{name: "pointer to pointer with DeepCopy, method name", types: typesVal{"Twice", "Leaf"}, method: "Clone", path: "./testdata", want: []byte(TwiceCloneFile)},
+		{name: "skip all, map values", types: typesVal{"Worker"}, skipAll: []string{"Queue[k]"}, path: "./testdata", want: []byte(SkipAllMapValuesFile)},
+		{name: "skip all, regular expression", types: typesVal{"Service", "Worker"}, skips: skipsVal{{"Deps": struct{}{}}}, skipAll: []string{".*logger"}, path: "./testdata", want: []byte(SkipAllLogger)},
+		{name: "slices of named maps", types: typesVal{"Configs"}, path: "./testdata", want: []byte(ConfigsFile)},
+		{name: "explained", types: typesVal{"Foo", "Bar"}, explain: true, path: "./testdata", want: []byte(FooBarExplainFile)},
+		{name: "explained interface implementations", types: typesVal{"EventRef"}, explain: true, ifaceImpls: map[string][]string{"Event": {"ClickEvent", "*KeyEvent", "PlainEvent"}}, path: "./testdata", want: []byte(EventRefExplainFile)},
+		{name: "runtime options", types: typesVal{"Session"}, runtimeOpts: true, optional: []string{"Cache", "History.Pages", "ID"}, path: "./testdata", want: []byte(SessionOptsFile)},
+		{name: "runtime options, functions, pointer", types: typesVal{"Session"}, runtimeOpts: true, optional: []string{"Cache"}, funcs: true, pointer: true, path: "./testdata", want: []byte(SessionOptsFuncsFile)},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			a := &app{
-				isPtrRecv: tt.pointer,
-				maxDepth:  tt.maxdepth,
+				isPtrRecv:       tt.pointer,
+				maxDepth:        tt.maxdepth,
+				into:            tt.into,
+				reuse:           tt.reuse,
+				skipAll:         tt.skipAll,
+				deep:            tt.deep,
+				skipTypes:       tt.skipTypes,
+				tests:           tt.tests,
+				genShallow:      tt.shallow,
+				genEqual:        tt.genEqual,
+				genSlice:        tt.genSlice,
+				explain:         tt.explain,
+				runtimeOpts:     tt.runtimeOpts,
+				optional:        tt.optional,
+				strict:          tt.strict,
+				allocator:       tt.allocator,
+				output:          tt.output,
+				nilMapAsEmpty:   tt.nilMapAsEmpty,
+				nilSliceAsEmpty: tt.nilSliceAsEmpty,
+				preserveCap:     tt.preserveCap,
+				aliasing:        tt.aliasing,
+				all:             tt.all,
+				funcs:           tt.funcs,
+				outPackage:      tt.outPackage,
+				ifaceImpls:      tt.ifaceImpls,
+				method:          tt.method,
+				ifaceMethod:     tt.ifaceMethod,
+				ifaceDynamic:    tt.ifaceDynamic,
+				importAliases:   tt.importAliases,
+				dropChan:        tt.dropChan,
+				deepKeys:        tt.deepKeys,
+				tag:             tt.tag,
+				license:         tt.license,
 			}
 			got, err := a.run(tt.path, tt.types, tt.skips)
 			if err != nil {
@@ -54,476 +272,6591 @@ func Test_run(t *testing.T) {
 			if diff := cmp.Diff(got, tt.want); diff != "" {
 				t.Errorf("generateFile() diff = %s", diff)
 			}
-		})
+			if diff := cmp.Diff(a.warnings, tt.warnings); diff != "" {
+				t.Errorf("warnings diff = %s", diff)
+			}
+		})
+	}
+}
+
+func Test_run_unexportedOutPackage(t *testing.T) {
+	a := &app{funcs: true, outPackage: "copies"}
+	if _, err := a.run("./testdata", typesVal{"Tree", "hiddenTree"}, nil); err == nil {
+		t.Error("expected an error copying an unexported type in another package")
+	}
+}
+
+func Test_run_invalidIfaceImpls(t *testing.T) {
+	tests := []struct {
+		name       string
+		ifaceImpls map[string][]string
+	}{
+		{name: "unknown interface", ifaceImpls: map[string][]string{"Unknown": {"ClickEvent"}}},
+		{name: "not an interface", ifaceImpls: map[string][]string{"ClickEvent": {"KeyEvent"}}},
+		{name: "unknown implementation", ifaceImpls: map[string][]string{"Event": {"Unknown"}}},
+		{name: "pointer receiver", ifaceImpls: map[string][]string{"Event": {"KeyEvent"}}},
+		{name: "unknown package", ifaceImpls: map[string][]string{"Event": {"example.com/unknown.KeyEvent"}}},
+		{name: "unknown type of package", ifaceImpls: map[string][]string{"Event": {"github.com/globusdigital/deep-copy/testdata/handlers.Unknown"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &app{ifaceImpls: tt.ifaceImpls}
+			if _, err := a.run("./testdata", typesVal{"EventLog"}, nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func Test_run_invalidDeep(t *testing.T) {
+	tests := []struct {
+		name  string
+		skips skipsVal
+	}{
+		{name: "interface without implementations"},
+		{name: "skipped selector", skips: skipsVal{{"Last": struct{}{}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &app{deep: []string{"Last"}, quiet: true}
+			if _, err := a.run("./testdata", typesVal{"EventLog"}, tt.skips); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func Test_run_vendored(t *testing.T) {
+	// The vendored module has no go.sum, its dependencies are only available
+	// from its vendor directory.
+	t.Setenv("GOFLAGS", "-mod=vendor")
+
+	a := &app{}
+	got, err := a.run("./testdata/vendored", typesVal{"Vendored"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(normalizeComment(got), []byte(VendoredFile)); diff != "" {
+		t.Errorf("generateFile() diff = %s", diff)
+	}
+}
+
+func Test_licenseHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{name: "copyright", text: "Copyright {{.Year}} {{.Holder}}\n\nLicensed under MIT.\n", want: "// Copyright 2020 Globus\n//\n// Licensed under MIT.\n\n"},
+		{name: "no placeholders", text: "Licensed under MIT.", want: "// Licensed under MIT.\n\n"},
+		{name: "invalid template", text: "Copyright {{.Year", wantErr: true},
+		{name: "unknown placeholder", text: "Copyright {{.Owner}}", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := licenseHeader(tt.text, 2020, "Globus")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("licenseHeader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("licenseHeader() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseImportMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "aliases", text: "# pinned\nk8s.io/api/core/v1 corev1\n\n  example.com/go-dep dep\n", want: map[string]string{"k8s.io/api/core/v1": "corev1", "example.com/go-dep": "dep"}},
+		{name: "missing alias", text: "k8s.io/api/core/v1\n", wantErr: true},
+		{name: "invalid alias", text: "k8s.io/api/core/v1 core-v1\n", wantErr: true},
+		{name: "blank alias", text: "k8s.io/api/core/v1 _\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseImportMap(strings.NewReader(tt.text))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseImportMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseImportMap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_run_typeTree(t *testing.T) {
+	var buf bytes.Buffer
+	a := &app{typeTree: &buf}
+	if _, err := a.run("./testdata", typesVal{"Kid", "Schedule"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `Kid (struct, DeepCopy)
+  Name string (basic)
+  Kids []Kid (slice)
+    [i] Kid (struct, DeepCopy, recursive)
+Schedule (struct)
+  Start time.Time (struct, value type)
+  Dates []time.Time (slice)
+    [i] time.Time (struct, value type)
+  Due map[string]time.Time (map)
+    key string (basic)
+    [k] time.Time (struct, value type)
+`
+	if diff := cmp.Diff(buf.String(), want); diff != "" {
+		t.Errorf("type tree diff = %s", diff)
+	}
+}
+
+func Test_generateFile_noFormat(t *testing.T) {
+	fn := []byte("func  (o Foo) DeepCopy( ) Foo {")
+
+	got, err := generateFile("testdata", "", nil, nil, [][]byte{fn}, true)
+	if err != nil {
+		t.Fatalf("generateFile() error = %v", err)
+	}
+	if !bytes.Contains(got, fn) {
+		t.Errorf("generateFile() = %s, want the unformatted %s", got, fn)
+	}
+
+	if _, err := generateFile("testdata", "", nil, nil, [][]byte{fn}, false); err == nil {
+		t.Error("generateFile() of invalid code succeeded when formatting")
+	}
+}
+
+func Test_jsonOutput(t *testing.T) {
+	a := &app{quiet: true}
+	code, err := a.run("./testdata", typesVal{"Readers"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := jsonOutput(code, a.warnings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		Code     *string  `json:"code"`
+		Imports  []string `json:"imports"`
+		Warnings []string `json:"warnings"`
+	}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, b)
+	}
+
+	if got.Code == nil {
+		t.Fatal("no code in the JSON output")
+	}
+	if formatted, err := format.Source([]byte(*got.Code)); err != nil || string(formatted) != *got.Code {
+		t.Errorf("code isn't formatted Go (%v):\n%s", err, *got.Code)
+	}
+	if diff := cmp.Diff(got.Imports, []string{"io"}); diff != "" {
+		t.Errorf("imports diff = %s", diff)
+	}
+	if diff := cmp.Diff(got.Warnings, a.warnings); diff != "" {
+		t.Errorf("warnings diff = %s", diff)
+	}
+}
+
+func Test_outputVal_mode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out_gen.go")
+
+	var mode fileModeVal
+	if err := mode.Set("0640"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mode.Set("0999"); err == nil {
+		t.Error("expected an error for an invalid mode")
+	}
+
+	f := outputVal{mode: os.FileMode(mode)}
+	if err := f.Set(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("output file created before writing it: %v", err)
+	}
+
+	w, err := f.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("package out\n")); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The umask may only clear permission bits, not set any.
+	if perm := fi.Mode().Perm(); perm&^0640 != 0 || perm&0600 != 0600 {
+		t.Errorf("output file mode = %#o, want %#o modulo the umask", perm, 0640)
+	}
+}
+
+func Test_run_strict(t *testing.T) {
+	a := &app{strict: true}
+	if _, err := a.run("./testdata", typesVal{"Readers"}, nil); err == nil {
+		t.Error("expected an error for the shared interface values")
+	}
+}
+
+func Test_listTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{name: "generic types", path: "./testdata/generic", want: []string{"Box", "Pair"}},
+		{name: "pointer that implements DeepCopy", path: "./testdata/pointer_that_implements_deepcopy/somepkg", want: []string{"SomeStruct"}},
+		{name: "only value members", path: "./testdata/pointer_that_implements_deepcopy/anotherpkg"},
+		{name: "unexported members", path: "./testdata/unexportedpkg", want: []string{"WithUnexported"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &app{}
+			got, err := a.listTypes(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("listTypes() diff = %s", diff)
+			}
+		})
+	}
+}
+
+func Test_pointerToSliceOfPointers(t *testing.T) {
+	items := []*testdata.PtrItem{{Value: 1, Tags: []string{"a"}}, nil}
+	m := map[string]*testdata.PtrItem{"a": {Value: 1}}
+	item := &testdata.PtrItem{Value: 1}
+	src := testdata.PtrSlice{Items: &items, Maps: &m}
+	srcPtr := testdata.PtrPtr{Item: &item}
+
+	cp := src.DeepCopy()
+	cpPtr := srcPtr.DeepCopy()
+
+	(*cp.Items)[0].Value = 2
+	(*cp.Items)[0].Tags[0] = "b"
+	(*cp.Items)[1] = &testdata.PtrItem{}
+	(*cp.Maps)["a"].Value = 2
+	(**cpPtr.Item).Value = 2
+
+	if items[0].Value != 1 || items[0].Tags[0] != "a" {
+		t.Errorf("source element modified through the copy: %+v", items[0])
+	}
+	if items[1] != nil {
+		t.Errorf("source backing array modified through the copy: %+v", items[1])
+	}
+	if m["a"].Value != 1 {
+		t.Errorf("source map value modified through the copy: %+v", m["a"])
+	}
+	if item.Value != 1 {
+		t.Errorf("source pointer modified through the copy: %+v", item)
+	}
+}
+
+func Test_outPackageFuncs(t *testing.T) {
+	src := testdata.Tree{
+		Root:   &testdata.Node{Name: "root", Children: []*testdata.Node{{Name: "leaf"}}},
+		Nodes:  []testdata.Node{{Name: "node"}},
+		ByName: map[string]*testdata.Node{"root": {Name: "root"}},
+	}
+
+	cp := copies.DeepCopyTree(src)
+
+	cp.Root.Children[0].Name = "changed"
+	cp.Nodes[0].Name = "changed"
+	cp.ByName["root"].Name = "changed"
+
+	if src.Root.Children[0].Name != "leaf" {
+		t.Errorf("source child modified through the copy: %+v", src.Root.Children[0])
+	}
+	if src.Nodes[0].Name != "node" {
+		t.Errorf("source node modified through the copy: %+v", src.Nodes[0])
+	}
+	if src.ByName["root"].Name != "root" {
+		t.Errorf("source map value modified through the copy: %+v", src.ByName["root"])
+	}
+}
+
+func Test_ifaceImpls(t *testing.T) {
+	click := testdata.ClickEvent{Tags: []string{"a"}}
+	key := &testdata.KeyEvent{Keys: map[string]int{"a": 1}}
+	src := testdata.EventLog{
+		Events: map[string][]testdata.Event{"ui": {click, key, testdata.PlainEvent{ID: 1}, nil}},
+		Last:   key,
+	}
+
+	cp := src.DeepCopy()
+
+	cp.Events["ui"][0].(testdata.ClickEvent).Tags[0] = "b"
+	cp.Events["ui"][1].(*testdata.KeyEvent).Keys["a"] = 2
+	cp.Last.(*testdata.KeyEvent).Keys["b"] = 2
+	cp.Events["ui"][2] = nil
+
+	if click.Tags[0] != "a" {
+		t.Errorf("source click event modified through the copy: %+v", click)
+	}
+	if len(key.Keys) != 1 || key.Keys["a"] != 1 {
+		t.Errorf("source key event modified through the copy: %+v", key)
+	}
+	if src.Events["ui"][2] == nil {
+		t.Error("source slice modified through the copy")
+	}
+	if cp.Events["ui"][3] != nil {
+		t.Errorf("cp.Events[%q][3] = %v, want nil", "ui", cp.Events["ui"][3])
+	}
+}
+
+func Test_ifaceImplsArray(t *testing.T) {
+	click := testdata.ClickEvent{Tags: []string{"a"}}
+	key := &testdata.KeyEvent{Keys: map[string]int{"a": 1}}
+	src := testdata.EventRing{Recent: [3]testdata.Event{click, key, testdata.PlainEvent{ID: 1}}}
+
+	cp := src.DeepCopy()
+
+	if !reflect.DeepEqual(cp, src) {
+		t.Fatalf("cp = %#v, want %#v", cp, src)
+	}
+	if cp.Recent[1] == src.Recent[1] {
+		t.Error("cp.Recent[1] is shared with the source")
+	}
+
+	cp.Recent[0].(testdata.ClickEvent).Tags[0] = "b"
+	cp.Recent[1].(*testdata.KeyEvent).Keys["a"] = 2
+	cp.Recent[2] = nil
+
+	if click.Tags[0] != "a" {
+		t.Errorf("source click event modified through the copy: %+v", click)
+	}
+	if key.Keys["a"] != 1 {
+		t.Errorf("source key event modified through the copy: %+v", key)
+	}
+	if src.Recent[2] == nil {
+		t.Error("source array modified through the copy")
+	}
+}
+
+func Test_ifaceImplsNamedInterface(t *testing.T) {
+	mux := handlers.Mux{Routes: map[string]string{"/": "index"}}
+	src := testdata.Routes{
+		Default: mux,
+		ByPath:  map[string]testdata.NamedHandler{"/mux": mux, "/print": testdata.PrintHandler{Prefix: ">"}, "/nil": nil},
+	}
+
+	cp := src.DeepCopy()
+
+	if !reflect.DeepEqual(cp, src) {
+		t.Fatalf("cp = %#v, want %#v", cp, src)
+	}
+
+	cp.Default.(handlers.Mux).Routes["/"] = "home"
+	cp.ByPath["/mux"].(handlers.Mux).Routes["/"] = "home"
+
+	if mux.Routes["/"] != "index" {
+		t.Errorf("source mux modified through the copy: %+v", mux)
+	}
+}
+
+func Test_ifaceImplsPointer(t *testing.T) {
+	for _, event := range []testdata.Event{
+		testdata.ClickEvent{Tags: []string{"a"}},
+		&testdata.KeyEvent{Keys: map[string]int{"a": 1}},
+	} {
+		src := testdata.EventRef{Event: &event}
+
+		cp := src.DeepCopy()
+		if cp.Event == src.Event {
+			t.Fatal("pointer to the interface is shared with the copy")
+		}
+
+		switch e := (*cp.Event).(type) {
+		case testdata.ClickEvent:
+			e.Tags[0] = "b"
+			if event.(testdata.ClickEvent).Tags[0] != "a" {
+				t.Errorf("source click event modified through the copy: %+v", event)
+			}
+		case *testdata.KeyEvent:
+			e.Keys["a"] = 2
+			if event.(*testdata.KeyEvent).Keys["a"] != 1 {
+				t.Errorf("source key event modified through the copy: %+v", event)
+			}
+		default:
+			t.Errorf("copied event of type %T", e)
+		}
+	}
+}
+
+func Test_ifaceImplsOtherPackages(t *testing.T) {
+	logger := &loggers.Logger{Lines: []string{"a"}}
+	mux := handlers.Mux{Routes: map[string]string{"/": "root"}}
+	src := testdata.HandlerChain{Handlers: []handlers.Handler{logger, mux}}
+
+	cp := src.DeepCopy()
+
+	cp.Handlers[0].Handle("b")
+	cp.Handlers[1].(handlers.Mux).Routes["/"] = "changed"
+
+	if len(logger.Lines) != 1 {
+		t.Errorf("source logger modified through the copy: %+v", logger)
+	}
+	if mux.Routes["/"] != "root" {
+		t.Errorf("source mux modified through the copy: %+v", mux)
+	}
+}
+
+func Test_nilSliceElements(t *testing.T) {
+	src := testdata.PtrList{Items: []*testdata.PtrItem{
+		{Value: 1, Tags: []string{"a"}},
+		nil,
+		{Value: 2},
+		nil,
+	}}
+
+	cp := src.DeepCopy()
+
+	if len(cp.Items) != len(src.Items) {
+		t.Fatalf("len(cp.Items) = %d, want %d", len(cp.Items), len(src.Items))
+	}
+	for i := range src.Items {
+		switch {
+		case src.Items[i] == nil:
+			if cp.Items[i] != nil {
+				t.Errorf("cp.Items[%d] = %v, want nil", i, cp.Items[i])
+			}
+		case cp.Items[i] == src.Items[i]:
+			t.Errorf("cp.Items[%d] shares its pointer with the source", i)
+		case cp.Items[i].Value != src.Items[i].Value:
+			t.Errorf("cp.Items[%d].Value = %d, want %d", i, cp.Items[i].Value, src.Items[i].Value)
+		}
+	}
+
+	cp.Items[0].Tags[0] = "b"
+	if src.Items[0].Tags[0] != "a" {
+		t.Errorf("src.Items[0].Tags[0] = %q, want %q", src.Items[0].Tags[0], "a")
+	}
+}
+
+func Test_mapChanValues(t *testing.T) {
+	src := testdata.ChanMap{Chans: map[string]chan int{
+		"buffered": make(chan int, 3),
+		"nil":      nil,
+	}}
+
+	cp := src.DeepCopy()
+
+	if len(cp.Chans) != len(src.Chans) {
+		t.Fatalf("len(cp.Chans) = %d, want %d", len(cp.Chans), len(src.Chans))
+	}
+	if cp.Chans["buffered"] == src.Chans["buffered"] {
+		t.Errorf("cp.Chans[%q] is the source channel", "buffered")
+	}
+	if c := cap(cp.Chans["buffered"]); c != 3 {
+		t.Errorf("cap(cp.Chans[%q]) = %d, want 3", "buffered", c)
+	}
+	if ch, ok := cp.Chans["nil"]; !ok || ch != nil {
+		t.Errorf("cp.Chans[%q] = %v, %v, want nil, true", "nil", ch, ok)
+	}
+}
+
+func Test_dropChan(t *testing.T) {
+	src := testdata.DropChan{
+		Done:   make(chan struct{}),
+		Queues: []chan int{make(chan int)},
+		ByName: map[string]chan int{"a": make(chan int)},
+	}
+
+	cp := src.DeepCopy()
+
+	if cp.Done != nil {
+		t.Error("cp.Done is not nil")
+	}
+	if len(cp.Queues) != 1 || cp.Queues[0] != nil {
+		t.Errorf("cp.Queues = %v, want a single nil channel", cp.Queues)
+	}
+	if ch, ok := cp.ByName["a"]; !ok || ch != nil {
+		t.Errorf("cp.ByName[%q] = %v, %v, want nil, true", "a", ch, ok)
+	}
+	if src.Done == nil || src.Queues[0] == nil || src.ByName["a"] == nil {
+		t.Error("source channels modified by the copy")
+	}
+}
+
+func Test_mapArrayValues(t *testing.T) {
+	src := testdata.MapArray{
+		Items: map[string][3]testdata.ArrayItem{"a": {{Tags: []string{"a"}}, {}, {Tags: []string{"c"}}}},
+		Grid:  [2][]int{{1}, nil},
+	}
+
+	cp := src.DeepCopy()
+
+	cp.Items["a"][0].Tags[0] = "changed"
+	cp.Items["a"][2].Tags[0] = "changed"
+	cp.Grid[0][0] = 2
+
+	if src.Items["a"][0].Tags[0] != "a" || src.Items["a"][2].Tags[0] != "c" {
+		t.Errorf("source array elements modified through the copy: %+v", src.Items["a"])
+	}
+	if src.Grid[0][0] != 1 {
+		t.Errorf("source array element modified through the copy: %v", src.Grid)
+	}
+	if cp.Items["a"][1].Tags != nil || cp.Grid[1] != nil {
+		t.Error("nil array element references aren't nil in the copy")
+	}
+}
+
+func Test_nilReusedCopies(t *testing.T) {
+	var src testdata.Labeled
+
+	cp := src.DeepCopy()
+	if cp.Ptr != nil || cp.Value.Names != nil {
+		t.Errorf("empty labels aren't empty in the copy: %+v", cp)
+	}
+
+	src = testdata.Labeled{Value: testdata.Labels{Names: []string{"a"}}, Ptr: &testdata.Labels{Names: []string{"b"}}}
+
+	cp = src.DeepCopy()
+	cp.Value.Names[0] = "changed"
+	cp.Ptr.Names[0] = "changed"
+
+	if src.Value.Names[0] != "a" || src.Ptr.Names[0] != "b" {
+		t.Errorf("source labels modified through the copy: %+v, %+v", src.Value, *src.Ptr)
+	}
+}
+
+func Test_mapPointerValues(t *testing.T) {
+	src := testdata.ItemIndex{Items: map[string]*testdata.ArrayItem{"a": {Tags: []string{"a"}}, "nil": nil}}
+
+	cp := src.DeepCopy()
+
+	if cp.Items["a"] == src.Items["a"] {
+		t.Fatal("map value pointer is shared with the copy")
+	}
+	cp.Items["a"].Tags[0] = "changed"
+
+	if src.Items["a"].Tags[0] != "a" {
+		t.Errorf("source map value modified through the copy: %+v", *src.Items["a"])
+	}
+	if v, ok := cp.Items["nil"]; !ok || v != nil {
+		t.Errorf("nil map value isn't nil in the copy: %v", v)
+	}
+}
+
+func Test_locks(t *testing.T) {
+	src := testdata.CounterRef{Counter: &testdata.Counter{
+		Counts: map[string]int{"a": 1},
+		Stats:  testdata.Stats{Hits: []int{1}, Name: "stats"},
+	}}
+
+	cp := src.DeepCopy()
+	cp.Counter.Counts["a"] = 2
+	cp.Counter.Stats.Hits[0] = 2
+
+	if src.Counter.Counts["a"] != 1 || src.Counter.Stats.Hits[0] != 1 {
+		t.Errorf("source counter modified through the copy: %+v", src.Counter)
+	}
+	if cp.Counter.Stats.Name != "stats" {
+		t.Errorf("copied counter name = %q, want %q", cp.Counter.Stats.Name, "stats")
+	}
+
+	// The generated code copies no locks, as reported by the copylocks check.
+	out, err := exec.Command("go", "vet", "./testdata").CombinedOutput()
+	if err != nil {
+		t.Errorf("go vet: %v\n%s", err, out)
+	}
+}
+
+func Test_selfSlice(t *testing.T) {
+	src := testdata.Kid{Name: "root", Kids: []testdata.Kid{
+		{Name: "a", Kids: []testdata.Kid{{Name: "aa"}}},
+		{Name: "b"},
+	}}
+
+	cp := src.DeepCopy()
+	cp.Kids[0].Name = "changed"
+	cp.Kids[0].Kids[0].Name = "changed"
+
+	if src.Kids[0].Name != "a" || src.Kids[0].Kids[0].Name != "aa" {
+		t.Errorf("source kids modified through the copy: %+v", src.Kids)
+	}
+	if cp.Kids[1].Kids != nil {
+		t.Errorf("nil kids aren't nil in the copy: %v", cp.Kids[1].Kids)
+	}
+}
+
+func Test_arrayMaps(t *testing.T) {
+	src := testdata.ArrayMaps{Counts: [2]map[string]int{{"a": 1}, nil}}
+
+	cp := src.DeepCopy()
+	cp.Counts[0]["a"] = 2
+
+	if src.Counts[0]["a"] != 1 {
+		t.Errorf("source array map modified through the copy: %v", src.Counts[0])
+	}
+	if cp.Counts[1] != nil {
+		t.Errorf("nil array map isn't nil in the copy: %v", cp.Counts[1])
+	}
+}
+
+func Test_directedChans(t *testing.T) {
+	in, out := make(chan int), make(chan string)
+	src := testdata.Directed{In: in, Out: out, Both: make(chan int, 2)}
+
+	cp := src.DeepCopy()
+
+	if cp.In != src.In || cp.Out != src.Out {
+		t.Error("directional channels aren't shared with the copy")
+	}
+	if cp.Both == src.Both || cap(cp.Both) != 2 {
+		t.Errorf("bidirectional channel isn't a new one of the same capacity: %v", cap(cp.Both))
+	}
+}
+
+func Test_embeddedExternal(t *testing.T) {
+	src := testdata.EmbeddedExternal{Name: "a"}
+	src.Exported = []int{1}
+
+	cp := src.DeepCopy()
+	cp.Exported[0] = 2
+
+	if src.Exported[0] != 1 {
+		t.Errorf("source embedded slice modified through the copy: %v", src.Exported)
+	}
+}
+
+func Test_arrayReuse(t *testing.T) {
+	src := testdata.ItemArrays{
+		Items:  [4]*testdata.ArrayItem{{Tags: []string{"a"}}, nil},
+		Labels: [4]testdata.Labels{{Names: []string{"b"}}},
+	}
+
+	cp := src.DeepCopy()
+	if cp.Items[0] == src.Items[0] {
+		t.Fatal("array element pointer is shared with the copy")
+	}
+	cp.Items[0].Tags[0] = "changed"
+	cp.Labels[0].Names[0] = "changed"
+
+	if src.Items[0].Tags[0] != "a" || src.Labels[0].Names[0] != "b" {
+		t.Errorf("source array elements modified through the copy: %+v, %+v", *src.Items[0], src.Labels[0])
+	}
+	if cp.Items[1] != nil {
+		t.Errorf("nil array element isn't nil in the copy: %v", cp.Items[1])
+	}
+}
+
+func Test_embeddedValueType(t *testing.T) {
+	now := time.Now()
+	src := testdata.MyTime{Time: now, Zones: []string{"UTC"}}
+
+	cp := src.DeepCopy()
+	cp.Zones[0] = "changed"
+
+	if !cp.Equal(now) {
+		t.Errorf("copied time = %v, want %v", cp.Time, now)
+	}
+	if src.Zones[0] != "UTC" {
+		t.Errorf("source zones modified through the copy: %v", src.Zones)
+	}
+}
+
+func Test_externalGenericContainers(t *testing.T) {
+	src := testdata.Inventory{
+		Stock:  containers.OrderedMap[string, []int]{"a": {1, 2}},
+		Owners: containers.OrderedMap[string, *testdata.Kid]{"b": {Name: "kid"}},
+		Names:  containers.List[string]{"name"},
+	}
+
+	cp := src.DeepCopy()
+	cp.Stock["a"][0] = 10
+	cp.Owners["b"].Name = "changed"
+	cp.Names[0] = "changed"
+
+	if src.Stock["a"][0] != 1 {
+		t.Errorf("source stock modified through the copy: %v", src.Stock)
+	}
+	if src.Owners["b"].Name != "kid" {
+		t.Errorf("source owner modified through the copy: %+v", src.Owners["b"])
+	}
+	if src.Names[0] != "name" {
+		t.Errorf("source names modified through the copy: %v", src.Names)
+	}
+}
+
+func Test_sharedKeys(t *testing.T) {
+	owner := &testdata.Kid{Name: "owner"}
+	key := testdata.CompositeKey{ID: 1, Owner: owner}
+	src := testdata.Keyed{
+		ByKey:   map[testdata.CompositeKey]string{key: "value"},
+		ByOwner: map[*testdata.Kid][]int{owner: {1}},
+	}
+
+	cp := src.DeepCopy()
+
+	if cp.ByKey[key] != "value" {
+		t.Errorf("cp.ByKey[key] = %q, want the shared key to match", cp.ByKey[key])
+	}
+	cp.ByOwner[owner][0] = 10
+	if src.ByOwner[owner][0] != 1 {
+		t.Errorf("source values modified through the copy: %v", src.ByOwner[owner])
+	}
+}
+
+func Test_deepEqual(t *testing.T) {
+	src := testdata.Snapshot{
+		Name:   "snapshot",
+		Tags:   []string{"tag"},
+		Scores: map[string][]int{"a": {1, 2}},
+		Owner:  &testdata.Kid{Name: "owner", Kids: []testdata.Kid{{Name: "kid"}}},
+		Parent: &testdata.Snapshot{Name: "parent", Tags: []string{"parent"}},
+		Taken:  time.Now(),
+		Meta:   map[string]int{"a": 1},
+		Grid:   [2][]int{{1}, {2, 3}},
+	}
+
+	mutations := map[string]func(cp *testdata.Snapshot){
+		"tag":      func(cp *testdata.Snapshot) { cp.Tags[0] = "changed" },
+		"score":    func(cp *testdata.Snapshot) { cp.Scores["a"][1] = 20 },
+		"owner":    func(cp *testdata.Snapshot) { cp.Owner.Kids[0].Name = "changed" },
+		"parent":   func(cp *testdata.Snapshot) { cp.Parent.Tags = nil },
+		"meta":     func(cp *testdata.Snapshot) { cp.Meta = map[string]int{"a": 2} },
+		"grid":     func(cp *testdata.Snapshot) { cp.Grid[1][1] = 30 },
+		"new kid":  func(cp *testdata.Snapshot) { cp.Kids = append(cp.Kids, testdata.Kid{}) },
+		"no owner": func(cp *testdata.Snapshot) { cp.Owner = nil },
+	}
+	for name, mutate := range mutations {
+		t.Run(name, func(t *testing.T) {
+			cp := src.DeepCopy()
+			if !cp.DeepEqual(src) {
+				t.Fatal("fresh copy isn't deeply equal to its source")
+			}
+
+			mutate(&cp)
+			if cp.DeepEqual(src) || src.DeepEqual(cp) {
+				t.Error("mutated copy is deeply equal to its source")
+			}
+		})
+	}
+}
+
+func Test_aliasChains(t *testing.T) {
+	names := testdata.NameList{"name"}
+	src := testdata.Audited{
+		Labels:  testdata.LabelSet{"a": {"b"}},
+		Names:   []testdata.NameList{{"first"}},
+		Aliases: &names,
+	}
+
+	cp := src.DeepCopy()
+	cp.Labels["a"][0] = "changed"
+	cp.Names[0][0] = "changed"
+	(*cp.Aliases)[0] = "changed"
+
+	if src.Labels["a"][0] != "b" {
+		t.Errorf("source labels modified through the copy: %v", src.Labels)
+	}
+	if src.Names[0][0] != "first" || names[0] != "name" {
+		t.Errorf("source names modified through the copy: %v, %v", src.Names, names)
+	}
+}
+
+func Test_anonymousSliceElements(t *testing.T) {
+	var src testdata.TaggedItems
+	src.Items = append(src.Items, struct {
+		Name string
+		Tags []string `json:"tags"`
+	}{Name: "item", Tags: []string{"tag"}})
+	src.Refs = append(src.Refs, &struct{ Tags []string }{Tags: []string{"ref"}})
+
+	cp := src.DeepCopy()
+	cp.Items[0].Tags[0] = "changed"
+	cp.Refs[0].Tags[0] = "changed"
+
+	if src.Items[0].Tags[0] != "tag" {
+		t.Errorf("source item tags modified through the copy: %v", src.Items[0].Tags)
+	}
+	if src.Refs[0].Tags[0] != "ref" {
+		t.Errorf("source ref tags modified through the copy: %v", src.Refs[0].Tags)
+	}
+}
+
+func Test_preservedCapacity(t *testing.T) {
+	src := testdata.Buffers{
+		Raw:    make([]byte, 1, 8),
+		Ints:   make([]int, 2, 16),
+		Named:  make(testdata.Bytes, 0, 4),
+		Nested: [][]byte{make([]byte, 3, 32)},
+	}
+
+	cp := src.DeepCopy()
+
+	for _, tt := range []struct {
+		name      string
+		got, want int
+	}{
+		{"Raw", cap(cp.Raw), cap(src.Raw)},
+		{"Ints", cap(cp.Ints), cap(src.Ints)},
+		{"Named", cap(cp.Named), cap(src.Named)},
+		{"Nested", cap(cp.Nested), cap(src.Nested)},
+		{"Nested[0]", cap(cp.Nested[0]), cap(src.Nested[0])},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("cap(cp.%s) = %d, want %d", tt.name, tt.got, tt.want)
+		}
+	}
+	if &cp.Raw[0] == &src.Raw[0] {
+		t.Error("cp.Raw shares its backing array with the source")
+	}
+}
+
+func Test_genericInstantiations(t *testing.T) {
+	label := "label"
+	src := testdata.Crates{
+		Ints:    testdata.Crate[int]{Items: []int{1}, Label: &label},
+		Strings: testdata.Crate[string]{Items: []string{"a"}},
+		Kids:    []testdata.Crate[*testdata.Kid]{{Items: []*testdata.Kid{{Name: "kid"}}}},
+	}
+
+	cp := src.DeepCopy()
+	cp.Ints.Items[0] = 10
+	*cp.Ints.Label = "changed"
+	cp.Strings.Items[0] = "changed"
+	cp.Kids[0].Items[0].Name = "changed"
+
+	if src.Ints.Items[0] != 1 || label != "label" {
+		t.Errorf("source ints modified through the copy: %v, %q", src.Ints.Items, label)
+	}
+	if src.Strings.Items[0] != "a" {
+		t.Errorf("source strings modified through the copy: %v", src.Strings.Items)
+	}
+	if src.Kids[0].Items[0].Name != "kid" {
+		t.Errorf("source kids modified through the copy: %+v", src.Kids[0].Items[0])
+	}
+}
+
+func Test_sets(t *testing.T) {
+	src := testdata.Sets{
+		Names:   map[string]struct{}{"a": {}, "b": {}},
+		Markers: make([]struct{}, 3),
+		Nested:  map[string]map[int]struct{}{"x": {1: {}}, "y": nil},
+	}
+
+	cp := src.DeepCopy()
+
+	if !reflect.DeepEqual(cp, src) {
+		t.Fatalf("cp = %#v, want %#v", cp, src)
+	}
+	cp.Names["c"] = struct{}{}
+	cp.Nested["x"][2] = struct{}{}
+	if _, ok := src.Names["c"]; ok {
+		t.Error("cp.Names shares its map with the source")
+	}
+	if _, ok := src.Nested["x"][2]; ok {
+		t.Error("cp.Nested[\"x\"] shares its map with the source")
+	}
+}
+
+func Test_genericMapValues(t *testing.T) {
+	// Values of a type parameter constrained by any are shared.
+	bar := &testdata.Bar{IntV: 1}
+	src := testdata.Cache[*testdata.Bar]{Entries: map[string]*testdata.Bar{"a": bar}}
+
+	cp := src.DeepCopy()
+
+	cp.Entries["b"] = nil
+	if _, ok := src.Entries["b"]; ok {
+		t.Error("cp.Entries shares its map with the source")
+	}
+	if cp.Entries["a"] != bar {
+		t.Error("cp.Entries[\"a\"] was deep copied, want it shared")
+	}
+
+	// Those constrained by a DeepCopy method are deep copied.
+	copier := testdata.CopierCache[testdata.Foo]{Entries: map[string]testdata.Foo{"a": {Map: map[string]*testdata.Bar{"bar": bar}}}}
+
+	copierCp := copier.DeepCopy()
+
+	if !reflect.DeepEqual(copierCp, copier) {
+		t.Fatalf("copierCp = %#v, want %#v", copierCp, copier)
+	}
+	if copierCp.Entries["a"].Map["bar"] == bar {
+		t.Error("copierCp.Entries[\"a\"] shares its values with the source")
+	}
+}
+
+func Test_interfaceCloneMethod(t *testing.T) {
+	src := testdata.ObjectList{
+		Items: []testdata.Object{&testdata.Pod{Labels: map[string]string{"app": "web"}}, nil},
+		Owner: &testdata.Pod{Labels: map[string]string{"team": "infra"}},
+	}
+
+	cp := src.DeepCopy()
+
+	if !reflect.DeepEqual(cp, src) {
+		t.Fatalf("cp = %#v, want %#v", cp, src)
+	}
+	if cp.Items[0] == src.Items[0] {
+		t.Error("cp.Items[0] is shared with the source")
+	}
+	if cp.Owner == src.Owner {
+		t.Error("cp.Owner is shared with the source")
+	}
+}
+
+func Test_funcMapValues(t *testing.T) {
+	var called []string
+	src := testdata.Callbacks{OnEvent: map[string]func(){
+		"start": func() { called = append(called, "start") },
+		"stop":  func() { called = append(called, "stop") },
+	}}
+
+	cp := src.DeepCopy()
+
+	if len(cp.OnEvent) != len(src.OnEvent) {
+		t.Fatalf("len(cp.OnEvent) = %d, want %d", len(cp.OnEvent), len(src.OnEvent))
+	}
+	cp.OnEvent["start"]()
+	cp.OnEvent["stop"]()
+	if diff := cmp.Diff(called, []string{"start", "stop"}); diff != "" {
+		t.Errorf("called diff = %s", diff)
+	}
+	delete(cp.OnEvent, "stop")
+	if _, ok := src.OnEvent["stop"]; !ok {
+		t.Error("cp.OnEvent shares its map with the source")
+	}
+}
+
+func Test_namedArrays(t *testing.T) {
+	bar := &testdata.Bar{Slice: []string{"a"}}
+	src := testdata.Grids{
+		Transform: testdata.Matrix{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+		Bars:      testdata.Ptrs{bar},
+		History:   []testdata.Matrix{{{2}}},
+		Nested:    map[string]testdata.Ptrs{"a": {nil, bar}},
+	}
+
+	cp := src.DeepCopy()
+
+	if !reflect.DeepEqual(cp, src) {
+		t.Fatalf("cp = %#v, want %#v", cp, src)
+	}
+	if cp.Bars[0] == bar || cp.Nested["a"][1] == bar {
+		t.Error("cp shares the pointers of the named arrays with the source")
+	}
+	cp.History[0][0][0] = 3
+	if src.History[0][0][0] != 2 {
+		t.Error("cp.History shares its backing array with the source")
+	}
+}
+
+func Test_pointerToMapOfSlices(t *testing.T) {
+	groups := map[string][]*testdata.Bar{"a": {{IntV: 1, Slice: []string{"x"}}, nil}}
+	src := testdata.BarIndex{Groups: &groups}
+
+	cp := src.DeepCopy()
+
+	if !reflect.DeepEqual(cp, src) {
+		t.Fatalf("cp = %#v, want %#v", cp, src)
+	}
+
+	(*cp.Groups)["a"][0].IntV = 2
+	(*cp.Groups)["a"][0].Slice[0] = "y"
+	(*cp.Groups)["b"] = nil
+
+	if bar := groups["a"][0]; bar.IntV != 1 || bar.Slice[0] != "x" {
+		t.Errorf("source bar modified through the copy: %+v", bar)
+	}
+	if _, ok := groups["b"]; ok {
+		t.Error("cp.Groups shares its map with the source")
+	}
+}
+
+func Test_genericElementsDeepCopy(t *testing.T) {
+	src := testdata.Packs{
+		Ints:    []testdata.Pack[int]{{Items: []int{1}}},
+		Strings: map[string]testdata.Pack[string]{"a": {Items: []string{"x"}}},
+		Ptrs:    []*testdata.Pack[int]{{Items: []int{2}}, nil},
+	}
+
+	cp := src.DeepCopy()
+
+	if diff := cmp.Diff(cp, src); diff != "" {
+		t.Fatalf("DeepCopy() diff = %s", diff)
+	}
+
+	cp.Ints[0].Items[0] = 3
+	cp.Strings["a"].Items[0] = "y"
+	cp.Ptrs[0].Items[0] = 4
+	if src.Ints[0].Items[0] != 1 || src.Strings["a"].Items[0] != "x" || src.Ptrs[0].Items[0] != 2 {
+		t.Errorf("source modified through the copy: %+v", src)
+	}
+}
+
+func Test_arrayMapKeys(t *testing.T) {
+	a, b := &testdata.Bar{IntV: 1}, &testdata.Bar{IntV: 2}
+	key := [2]*testdata.Bar{a, b}
+	src := testdata.PairIndex{Pairs: map[[2]*testdata.Bar][]string{key: {"x"}}}
+
+	cp := src.DeepCopy()
+
+	// The shared keys still look up the copied values.
+	values, ok := cp.Pairs[key]
+	if !ok {
+		t.Fatal("cp.Pairs has no value of the source key")
+	}
+	values[0] = "y"
+	if src.Pairs[key][0] != "x" {
+		t.Error("cp.Pairs shares its values with the source")
+	}
+}
+
+func Test_chanPointers(t *testing.T) {
+	signal := make(chan int, 2)
+	signal <- 1
+	recv := (<-chan int)(signal)
+	src := testdata.ChanPointers{Signal: &signal, Recv: &recv}
+
+	cp := src.DeepCopy()
+
+	if cp.Signal == src.Signal || cp.Recv == src.Recv {
+		t.Fatal("cp shares the pointers to channels with the source")
+	}
+	if *cp.Signal == signal {
+		t.Error("*cp.Signal is the channel of the source")
+	}
+	if cap(*cp.Signal) != 2 || len(*cp.Signal) != 0 {
+		t.Errorf("*cp.Signal has length %d and capacity %d, want 0 and 2", len(*cp.Signal), cap(*cp.Signal))
+	}
+	// Receive-only channels are shared.
+	if *cp.Recv != recv {
+		t.Error("*cp.Recv isn't the channel of the source")
+	}
+}
+
+func Test_namedMapSliceElements(t *testing.T) {
+	src := testdata.Configs{
+		Layers: []testdata.Config{{"a": "1"}, nil},
+		ByEnv:  map[string][]testdata.Config{"prod": {{"b": "2"}}},
+	}
+
+	cp := src.DeepCopy()
+
+	if diff := cmp.Diff(cp, src); diff != "" {
+		t.Fatalf("DeepCopy() diff = %s", diff)
+	}
+
+	cp.Layers[0]["a"] = "x"
+	cp.ByEnv["prod"][0]["b"] = "x"
+	if src.Layers[0]["a"] != "1" || src.ByEnv["prod"][0]["b"] != "2" {
+		t.Errorf("source modified through the copy: %+v", src)
+	}
+	if cp.Layers[1] != nil {
+		t.Errorf("cp.Layers[1] = %v, want nil", cp.Layers[1])
+	}
+}
+
+type stringerKey struct{ name string }
+
+func (k *stringerKey) String() string { return k.name }
+
+func Test_interfaceMapKeys(t *testing.T) {
+	key := &stringerKey{name: "a"}
+	src := testdata.StringerIndex{Bars: map[fmt.Stringer]*testdata.Bar{key: {IntV: 1, Slice: []string{"x"}}}}
+
+	cp := src.DeepCopy()
+
+	// Keys are shared, so the copy is still looked up by the keys of the
+	// source.
+	bar, ok := cp.Bars[key]
+	if !ok {
+		t.Fatal("cp.Bars isn't keyed by the keys of the source")
+	}
+	if bar == src.Bars[key] {
+		t.Fatal("cp.Bars shares its values with the source")
+	}
+
+	bar.IntV = 2
+	bar.Slice[0] = "y"
+	if src.Bars[key].IntV != 1 || src.Bars[key].Slice[0] != "x" {
+		t.Errorf("source modified through the copy: %+v", src.Bars[key])
+	}
+}
+
+func Test_fixedArrays(t *testing.T) {
+	src := testdata.FixedArrays{
+		Root:    testdata.ArrayNode{Name: "root", Children: [4]*testdata.ArrayNode{{Name: "a"}, nil, {Name: "c"}}},
+		Buffers: [3][]byte{[]byte("x"), nil, {}},
+		Counts:  [8]int{1, 2, 3},
+	}
+
+	cp := src.DeepCopy()
+
+	if diff := cmp.Diff(cp, src); diff != "" {
+		t.Fatalf("DeepCopy() diff = %s", diff)
+	}
+
+	cp.Root.Children[0].Name = "b"
+	cp.Buffers[0][0] = 'y'
+	cp.Counts[0] = 10
+	if src.Root.Children[0].Name != "a" || src.Buffers[0][0] != 'x' || src.Counts[0] != 1 {
+		t.Errorf("source modified through the copy: %+v", src)
+	}
+	if cp.Root.Children[1] != nil || cp.Buffers[1] != nil || cp.Buffers[2] == nil {
+		t.Errorf("nil and empty elements not kept as such: %+v", cp)
+	}
+}
+
+func Test_ifaceDynamic(t *testing.T) {
+	main := &testdata.Polygon{Sides: []float64{1, 2}}
+	circle := testdata.Circle{R: 1}
+	src := testdata.Sketch{
+		Main:    main,
+		Figures: []testdata.Figure{circle, (*testdata.Polygon)(nil), nil, &testdata.Polygon{Sides: []float64{3}}},
+	}
+
+	cp := src.DeepCopy()
+
+	if diff := cmp.Diff(cp, src); diff != "" {
+		t.Fatalf("DeepCopy() diff = %s", diff)
+	}
+
+	// Polygons are copied with their DeepCopy method.
+	if cp.Main == src.Main || cp.Figures[3] == src.Figures[3] {
+		t.Fatal("polygons shared with the copy")
+	}
+	cp.Main.(*testdata.Polygon).Sides[0] = 10
+	if main.Sides[0] != 1 {
+		t.Errorf("source modified through the copy: %+v", main)
+	}
+
+	// Circles, without one, and nil polygons are shared.
+	if cp.Figures[0] != circle || cp.Figures[1] != src.Figures[1] || cp.Figures[2] != nil {
+		t.Errorf("cp.Figures = %#v, want the shared circle, nil polygon and nil", cp.Figures[:3])
+	}
+}
+
+func Test_genericContainer(t *testing.T) {
+	n := 1
+	ints := testdata.Holder[int]{Items: []int{1, 2}, Ptr: &n}
+
+	cpInts := ints.DeepCopy()
+	cpInts.Items[0] = 10
+	*cpInts.Ptr = 10
+	if ints.Items[0] != 1 || n != 1 {
+		t.Errorf("source modified through the copy: %+v", ints)
+	}
+
+	// The values of type parameters are shared, here the pointers to bars.
+	bar := &testdata.Bar{IntV: 1}
+	bars := testdata.Holder[*testdata.Bar]{Items: []*testdata.Bar{bar}, Ptr: &bar}
+
+	cpBars := bars.DeepCopy()
+	if &cpBars.Items[0] == &bars.Items[0] || cpBars.Ptr == bars.Ptr {
+		t.Fatal("the slice, or pointer, of the holder is shared with the copy")
+	}
+	if cpBars.Items[0] != bar || *cpBars.Ptr != bar {
+		t.Error("the bars aren't shared with the copy")
+	}
+}
+
+func Test_nestedDeepCopyInto(t *testing.T) {
+	src := &testdata.Resource{
+		Meta:   testdata.ObjectMeta{Labels: map[string]string{"a": "1"}},
+		Owner:  &testdata.ObjectMeta{Labels: map[string]string{"b": "2"}},
+		Extras: []testdata.ObjectMeta{{Labels: map[string]string{"c": "3"}}},
+	}
+
+	var out testdata.Resource
+	src.DeepCopyInto(&out)
+
+	if diff := cmp.Diff(&out, src); diff != "" {
+		t.Fatalf("DeepCopyInto() diff = %s", diff)
+	}
+
+	out.Meta.Labels["a"] = "x"
+	out.Owner.Labels["b"] = "x"
+	out.Extras[0].Labels["c"] = "x"
+	if src.Meta.Labels["a"] != "1" || src.Owner.Labels["b"] != "2" || src.Extras[0].Labels["c"] != "3" {
+		t.Errorf("source modified through the copy: %+v", src)
+	}
+}
+
+func Test_recursiveImplied(t *testing.T) {
+	tree := testdata.BinaryTree{Size: 3, Root: &testdata.TreeNode{
+		Value: 1,
+		Left:  &testdata.TreeNode{Value: 2},
+		Right: &testdata.TreeNode{Value: 3, Left: &testdata.TreeNode{Value: 4}},
+	}}
+
+	cpTree := tree.DeepCopy()
+	if diff := cmp.Diff(cpTree, tree); diff != "" {
+		t.Fatalf("BinaryTree.DeepCopy() diff = %s", diff)
+	}
+	cpTree.Root.Right.Left.Value = 40
+	if tree.Root.Right.Left.Value != 4 {
+		t.Errorf("source tree modified through the copy: %+v", tree.Root.Right.Left)
+	}
+
+	org := testdata.Org{Root: testdata.Dept{
+		Head: &testdata.Employee{Skills: []string{"go"}},
+		Subs: []*testdata.Dept{{Head: &testdata.Employee{Skills: []string{"ops"}}}},
+	}}
+
+	cpOrg := org.DeepCopy()
+	if diff := cmp.Diff(cpOrg, org); diff != "" {
+		t.Fatalf("Org.DeepCopy() diff = %s", diff)
+	}
+	cpOrg.Root.Subs[0].Head.Skills[0] = "dev"
+	if org.Root.Subs[0].Head.Skills[0] != "ops" {
+		t.Errorf("source org modified through the copy: %+v", org.Root.Subs[0].Head)
+	}
+}
+
+func Test_copyMethod(t *testing.T) {
+	src := testdata.Document{
+		Title:   "doc",
+		Body:    testdata.Snippet{Lines: []string{"a"}},
+		Version: &testdata.Version{Parts: []int{1, 2}},
+		Notes:   []testdata.Snippet{{Lines: []string{"b"}}},
+	}
+
+	cp := src.Clone()
+	if diff := cmp.Diff(cp, src); diff != "" {
+		t.Fatalf("Clone() diff = %s", diff)
+	}
+
+	cp.Body.Lines[0] = "changed"
+	cp.Version.Parts[0] = 0
+	cp.Notes[0].Lines[0] = "changed"
+	if src.Body.Lines[0] != "a" || src.Version.Parts[0] != 1 || src.Notes[0].Lines[0] != "b" {
+		t.Errorf("Clone() shares members with the source: %+v", src)
+	}
+
+	// The legacy DeepCopy method is left as is.
+	if shallow := src.Body.DeepCopy(); &shallow.Lines[0] != &src.Body.Lines[0] {
+		t.Error("DeepCopy() of Snippet is no longer shallow")
+	}
+}
+
+func Test_skipTypes(t *testing.T) {
+	name := "sym"
+	src := &testdata.Ledger{
+		Owner:   testdata.Symbol{Name: &name},
+		Symbols: []testdata.Symbol{{Name: &name}},
+		Entries: map[string][]int{"a": {1}},
+	}
+
+	cp := src.DeepCopy()
+	if cp.Owner.Name != src.Owner.Name || cp.Symbols[0].Name != src.Symbols[0].Name {
+		t.Error("DeepCopy() copied the values of a skipped type")
+	}
+
+	cp.Symbols[0] = testdata.Symbol{}
+	cp.Entries["a"][0] = 2
+	if src.Symbols[0].Name == nil || src.Entries["a"][0] != 1 {
+		t.Errorf("DeepCopy() shares members with the source: %+v", src)
+	}
+
+	a := &app{skipTypes: []string{"Symbol"}, quiet: true}
+	if _, err := a.run("./testdata", typesVal{"Ledger"}, nil); err == nil {
+		t.Error("expected an error for a skipped type without its import path")
+	}
+}
+
+func Test_matchSelector(t *testing.T) {
+	tests := []struct {
+		pattern, sel string
+		want         bool
+	}{
+		{"*.Cache", "Inner.Cache", true},
+		{"*.Cache", "Items[i].Cache", true},
+		{"*.Cache", "Inner.Deep.Cache", false},
+		{"Inner.*.Entries", "Inner.Cache.Entries", true},
+		{"Inner.*.Entries", "Inner.Cache", false},
+		{"Field[k].*", "Field[k].Value", true},
+		{"Field[k].*", "Fieldk.Value", false},
+	}
+	for _, tt := range tests {
+		if got := matchSelector(tt.pattern, tt.sel); got != tt.want {
+			t.Errorf("matchSelector(%q, %q) = %v, want %v", tt.pattern, tt.sel, got, tt.want)
+		}
+	}
+}
+
+func Test_skipNested(t *testing.T) {
+	layer := func() testdata.Layer {
+		return testdata.Layer{
+			Meta:  testdata.Meta{Tags: []string{"a"}},
+			Store: testdata.Store{Entries: map[string][]byte{"k": {1}}, Hits: []int{1}},
+		}
+	}
+	src := testdata.Stack{Top: layer(), Bottom: layer(), Index: map[string]testdata.Layer{"l": layer()}}
+
+	cp := src.DeepCopy()
+	if diff := cmp.Diff(cp, src); diff != "" {
+		t.Fatalf("DeepCopy() diff = %s", diff)
+	}
+
+	// Every Meta, matched by the wildcard, Top.Store.Entries and the Hits of
+	// the map values are shared.
+	cp.Top.Meta.Tags[0] = "b"
+	cp.Bottom.Meta.Tags[0] = "b"
+	cp.Index["l"].Meta.Tags[0] = "b"
+	cp.Top.Store.Entries["k"] = nil
+	cp.Index["l"].Store.Hits[0] = 2
+	if src.Top.Meta.Tags[0] != "b" || src.Bottom.Meta.Tags[0] != "b" || src.Index["l"].Meta.Tags[0] != "b" || src.Top.Store.Entries["k"] != nil || src.Index["l"].Store.Hits[0] != 2 {
+		t.Errorf("DeepCopy() copied skipped members: %+v", src)
+	}
+
+	cp.Bottom.Store.Entries["k"] = nil
+	cp.Bottom.Store.Hits[0] = 2
+	if src.Bottom.Store.Entries["k"] == nil || src.Bottom.Store.Hits[0] != 1 {
+		t.Errorf("DeepCopy() shares members with the source: %+v", src)
+	}
+}
+
+func Test_preserveAliasing(t *testing.T) {
+	home := &testdata.Waypoint{Name: "home", Tags: []string{"start"}}
+	src := testdata.Route{
+		Start: home,
+		End:   &testdata.Waypoint{Name: "work"},
+		Stops: []*testdata.Waypoint{home, nil},
+		Named: map[string]*testdata.Waypoint{"home": home},
+	}
+
+	cp := src.DeepCopy()
+	if diff := cmp.Diff(cp, src); diff != "" {
+		t.Fatalf("DeepCopy() diff = %s", diff)
+	}
+	if cp.Start == src.Start {
+		t.Fatal("DeepCopy() shares the Start waypoint with the source")
+	}
+	if cp.Stops[0] != cp.Start || cp.Named["home"] != cp.Start {
+		t.Error("DeepCopy() lost the aliasing of the Start waypoint")
+	}
+	if cp.End == cp.Start {
+		t.Error("DeepCopy() aliased distinct waypoints")
+	}
+
+	a := &app{into: true, reuse: true, aliasing: true, quiet: true}
+	if _, err := a.run("./testdata", typesVal{"Route"}, nil); err == nil {
+		t.Error("expected an error when reusing the slices of the destination")
+	}
+}
+
+func Test_run_allErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		types typesVal
+		skips skipsVal
+	}{
+		{name: "with types", types: typesVal{"Item"}},
+		{name: "with skips", skips: skipsVal{{"Items": struct{}{}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &app{all: true, quiet: true}
+			if _, err := a.run("./testdata/all", tt.types, tt.skips); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func Test_typePattern(t *testing.T) {
+	tests := []struct {
+		kind, name string
+		want       bool
+	}{
+		{"*Spec", "PodSpec", true},
+		{"*Spec", "SpecList", false},
+		{"Config.*", "ConfigMap", true},
+		{"Config.*", "Config", true},
+		{"Config.*", "AppConfig", false},
+		{"Ro?te", "Route", true},
+	}
+	for _, tt := range tests {
+		if got := typePattern(tt.kind)(tt.name); got != tt.want {
+			t.Errorf("typePattern(%q)(%q) = %v, want %v", tt.kind, tt.name, got, tt.want)
+		}
+	}
+
+	if typePattern("Set[int]") != nil {
+		t.Error("typePattern() matches a single type as a pattern")
+	}
+
+	a := &app{quiet: true}
+	if _, err := a.run("./testdata/all", typesVal{"Nope*"}, nil); err == nil {
+		t.Error("expected an error for a pattern matching no type")
+	}
+}
+
+func Test_allTypes(t *testing.T) {
+	src := all.Shelf{
+		Manual: all.Manual{Data: []byte("m")},
+		Catalog: &all.Catalog{
+			Items:  all.Items{{Name: "a"}},
+			Index:  all.Index{"a": {Name: "a"}},
+			Owners: []string{"o"},
+		},
+	}
+
+	cp := src.DeepCopy()
+	if diff := cmp.Diff(cp, src); diff != "" {
+		t.Fatalf("DeepCopy() diff = %s", diff)
+	}
+
+	cp.Manual.Data[0] = 'x'
+	cp.Catalog.Items[0].Name = "b"
+	cp.Catalog.Index["a"].Name = "b"
+	cp.Catalog.Owners[0] = "p"
+	if src.Manual.Data[0] != 'm' || src.Catalog.Items[0].Name != "a" || src.Catalog.Index["a"].Name != "a" || src.Catalog.Owners[0] != "o" {
+		t.Errorf("DeepCopy() shares members with the source: %+v", src)
+	}
+}
+
+func Test_remoteMembers(t *testing.T) {
+	key := keypkg.Key{Kind: "k", Name: "n"}
+	src := testdata.Lookup{
+		ByKey:   map[keypkg.Key]*valpkg.Val{key: {Data: []byte("v")}},
+		Grouped: map[string][]valpkg.Val{"g": {{Data: []byte("v")}}},
+		Legacy:  map[keypkg.Key][2]*legacy.Val{key: {{Tags: []string{"t"}}}},
+	}
+	src.Pending = append(src.Pending, struct {
+		Key keypkg.Key
+		Val *legacy.Val
+	}{Key: key, Val: &legacy.Val{Tags: []string{"p"}}})
+
+	cp := src.DeepCopy()
+	if diff := cmp.Diff(cp, src); diff != "" {
+		t.Fatalf("DeepCopy() diff = %s", diff)
+	}
+
+	cp.ByKey[key].Data[0] = 'x'
+	cp.Grouped["g"][0].Data[0] = 'x'
+	cp.Legacy[key][0].Tags[0] = "x"
+	cp.Pending[0].Val.Tags[0] = "x"
+	if src.ByKey[key].Data[0] != 'v' || src.Grouped["g"][0].Data[0] != 'v' || src.Legacy[key][0].Tags[0] != "t" || src.Pending[0].Val.Tags[0] != "p" {
+		t.Errorf("DeepCopy() shares members with the source: %+v", src)
+	}
+}
+
+func Test_recursiveGeneric(t *testing.T) {
+	src := testdata.GenericTree[int]{Value: 1, Children: []*testdata.GenericTree[int]{
+		{Value: 2, Children: []*testdata.GenericTree[int]{{Value: 3}}},
+		nil,
+	}}
+
+	cp := src.DeepCopy()
+
+	cp.Children[0].Value = 20
+	cp.Children[0].Children[0].Value = 30
+
+	if src.Children[0].Value != 2 || src.Children[0].Children[0].Value != 3 {
+		t.Errorf("source tree modified through the copy: %+v", src.Children[0])
+	}
+	if cp.Children[1] != nil {
+		t.Errorf("cp.Children[1] = %+v, want nil", cp.Children[1])
+	}
+}
+
+// countingAllocator counts the values allocated on the heap.
+type countingAllocator struct {
+	news, slices, maps int
+}
+
+func (c *countingAllocator) NewAllocated() *testdata.Allocated {
+	c.news++
+	return new(testdata.Allocated)
+}
+
+func (c *countingAllocator) NewPtrItem() *testdata.PtrItem {
+	c.news++
+	return new(testdata.PtrItem)
+}
+
+func (c *countingAllocator) MakeAllocatedSlice(len int) []testdata.Allocated {
+	c.slices++
+	return make([]testdata.Allocated, len)
+}
+
+func (c *countingAllocator) MakeIntSlice(len int) []int {
+	c.slices++
+	return make([]int, len)
+}
+
+func (c *countingAllocator) MakePtrItemPtrSlice(len int) []*testdata.PtrItem {
+	c.slices++
+	return make([]*testdata.PtrItem, len)
+}
+
+func (c *countingAllocator) MakeStringSlice(len int) []string {
+	c.slices++
+	return make([]string, len)
+}
+
+func (c *countingAllocator) MakeStringToIntSliceMap(size int) map[string][]int {
+	c.maps++
+	return make(map[string][]int, size)
+}
+
+func Test_allocator(t *testing.T) {
+	src := testdata.Allocated{
+		Items:  []*testdata.PtrItem{{Tags: []string{"a"}}, nil},
+		Index:  map[string][]int{"a": {1}},
+		Child:  &testdata.Allocated{},
+		Values: []testdata.Allocated{{Items: []*testdata.PtrItem{}}},
+	}
+
+	var c countingAllocator
+	cp := src.DeepCopyWith(&c)
+
+	// Items and Items[0], Items[0].Tags, Index["a"], Values, Values[0].Items.
+	if c.news != 2 || c.slices != 5 || c.maps != 1 {
+		t.Errorf("allocated %d pointers, %d slices and %d maps, want 2, 5 and 1", c.news, c.slices, c.maps)
+	}
+
+	cp.Items[0].Tags[0] = "b"
+	cp.Index["a"][0] = 2
+	if src.Items[0].Tags[0] != "a" || src.Index["a"][0] != 1 {
+		t.Error("source modified through the copy")
+	}
+
+	if diff := cmp.Diff(src.DeepCopy(), src); diff != "" {
+		t.Errorf("DeepCopy() diff = %s", diff)
+	}
+}
+
+func Test_hookName(t *testing.T) {
+	tests := []struct {
+		kind, want string
+	}{
+		{"PtrItem", "PtrItem"},
+		{"*Leaf", "LeafPtr"},
+		{"[][]byte", "ByteSliceSlice"},
+		{"[4]int", "IntArray4"},
+		{"map[string][]int", "StringToIntSliceMap"},
+		{"map[Key]map[string]int", "KeyToStringToIntMapMap"},
+		{"pkg.Item", "PkgItem"},
+		{"Pair[string, []int]", "PairOfStringAndIntSlice"},
+		{"[]*Tree[map[string]int]", "TreeOfStringToIntMapPtrSlice"},
+	}
+	for _, tt := range tests {
+		if got := hookName(tt.kind); got != tt.want {
+			t.Errorf("hookName(%q) = %q, want %q", tt.kind, got, tt.want)
+		}
+	}
+}
+
+func Test_run_allocatorInto(t *testing.T) {
+	a := &app{allocator: true, into: true}
+	if _, err := a.run("./testdata", typesVal{"Allocated"}, nil); err == nil {
+		t.Error("expected an error using an allocator with DeepCopyInto")
+	}
+}
+
+func Test_run_allocatorSliceHelper(t *testing.T) {
+	a := &app{allocator: true, genSlice: true}
+	if _, err := a.run("./testdata", typesVal{"Book"}, nil); err == nil {
+		t.Error("expected an error using an allocator with slice helpers")
+	}
+}
+
+func Test_sliceHelper(t *testing.T) {
+	if got := testdata.DeepCopyBookSlice(nil); got != nil {
+		t.Errorf("DeepCopyBookSlice(nil) = %#v, want nil", got)
+	}
+
+	src := []testdata.Book{
+		{Title: "a", Tags: []string{"x"}, Sequel: &testdata.Book{Title: "b", Tags: []string{"y"}}},
+		{},
+	}
+
+	cp := testdata.DeepCopyBookSlice(src)
+
+	if diff := cmp.Diff(cp, src); diff != "" {
+		t.Fatalf("DeepCopyBookSlice() diff = %s", diff)
+	}
+
+	cp[0].Title = "c"
+	cp[0].Tags[0] = "z"
+	cp[0].Sequel.Tags[0] = "z"
+	if src[0].Title != "a" || src[0].Tags[0] != "x" || src[0].Sequel.Tags[0] != "y" {
+		t.Errorf("source modified through the copy: %+v", src)
+	}
+}
+
+func Test_run_reuseWithoutInto(t *testing.T) {
+	a := &app{reuse: true}
+	if _, err := a.run("./testdata", typesVal{"Frame"}, nil); err == nil {
+		t.Error("expected an error reusing slices without DeepCopyInto")
+	}
+}
+
+func Test_run_runtimeOptsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *app
+	}{
+		{name: "without optional selectors", a: &app{runtimeOpts: true}},
+		{name: "without runtime options", a: &app{optional: []string{"Cache"}}},
+		{name: "into", a: &app{runtimeOpts: true, optional: []string{"Cache"}, into: true}},
+		{name: "allocator", a: &app{runtimeOpts: true, optional: []string{"Cache"}, allocator: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.a.run("./testdata", typesVal{"Session"}, nil); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func Test_runtimeOpts(t *testing.T) {
+	src := testdata.Session{
+		ID:    "s",
+		Roles: []string{"admin"},
+		Cache: map[string][]byte{"k": []byte("v")},
+	}
+	src.History.Pages = []string{"/"}
+
+	for _, opts := range []testdata.SessionCopyOptions{{}, {CopyCache: true, CopyHistoryPages: true}} {
+		cp := src.DeepCopyOpts(opts)
+		if diff := cmp.Diff(cp, src); diff != "" {
+			t.Fatalf("DeepCopyOpts(%+v) diff = %s", opts, diff)
+		}
+
+		if &cp.Roles[0] == &src.Roles[0] {
+			t.Errorf("DeepCopyOpts(%+v) shares Roles", opts)
+		}
+		if shared := reflect.ValueOf(cp.Cache).Pointer() == reflect.ValueOf(src.Cache).Pointer(); shared == opts.CopyCache {
+			t.Errorf("DeepCopyOpts(%+v) shares Cache: %v", opts, shared)
+		}
+		if shared := &cp.History.Pages[0] == &src.History.Pages[0]; shared == opts.CopyHistoryPages {
+			t.Errorf("DeepCopyOpts(%+v) shares History.Pages: %v", opts, shared)
+		}
+	}
+
+	cp := src.DeepCopy()
+	cp.Cache["k"][0] = 'x'
+	cp.History.Pages[0] = "/x"
+	if string(src.Cache["k"]) != "v" || src.History.Pages[0] != "/" {
+		t.Errorf("source modified through the copy: %+v", src)
+	}
+}
+
+func Test_reuseInto(t *testing.T) {
+	src := &testdata.Frame{
+		Data:   []byte("abc"),
+		Bars:   []*testdata.Bar{{IntV: 1, Slice: []string{"a"}}},
+		Header: testdata.FrameHeader{Tags: []string{}},
+		Prev:   &testdata.FrameHeader{Tags: []string{"p"}},
+	}
+
+	oldBar := &testdata.Bar{IntV: 9}
+	out := &testdata.Frame{
+		Data:   make([]byte, 5, 16),
+		Bars:   []*testdata.Bar{oldBar, nil},
+		Header: testdata.FrameHeader{Tags: make([]string, 0, 4)},
+	}
+	data, bars, tags := out.Data, out.Bars, out.Header.Tags
+
+	src.DeepCopyInto(out)
+
+	if diff := cmp.Diff(out, src); diff != "" {
+		t.Fatalf("DeepCopyInto() diff = %s", diff)
+	}
+	if &out.Data[0] != &data[0] || &out.Bars[0] != &bars[0] || cap(out.Header.Tags) != cap(tags) {
+		t.Error("the slices of out with enough capacity weren't reused")
+	}
+	if cap(out.Data) != 16 {
+		t.Errorf("cap(out.Data) = %d, want 16", cap(out.Data))
+	}
+	// The pointed-to elements of out are reused.
+	if out.Bars[0] != oldBar || oldBar.IntV != 1 {
+		t.Errorf("out.Bars[0] = %p %+v, want the reused %p", out.Bars[0], out.Bars[0], oldBar)
+	}
+
+	out.Data[0] = 'x'
+	out.Bars[0].Slice[0] = "b"
+	out.Prev.Tags[0] = "q"
+	if string(src.Data) != "abc" || src.Bars[0].Slice[0] != "a" || src.Prev.Tags[0] != "p" {
+		t.Errorf("source modified through the copy: %+v", src)
+	}
+
+	// Slices without enough capacity are made anew.
+	small := &testdata.Frame{Data: make([]byte, 0, 1)}
+	src.DeepCopyInto(small)
+	if string(small.Data) != "abc" || cap(small.Data) < 3 {
+		t.Errorf("small.Data = %q, of capacity %d", small.Data, cap(small.Data))
+	}
+
+	samples, prev := testdata.Samples{1, 2}, make(testdata.Samples, 0, 8)
+	samples.DeepCopyInto(&prev)
+	if diff := cmp.Diff(prev, samples); diff != "" || cap(prev) != 8 {
+		t.Errorf("DeepCopyInto() diff = %s, of capacity %d", diff, cap(prev))
+	}
+}
+
+func Test_reuseIntoElements(t *testing.T) {
+	src := &testdata.Track{Frames: []*testdata.Frame{{Data: []byte("a")}, nil, {Data: []byte("c")}}}
+
+	first, second := &testdata.Frame{Data: make([]byte, 0, 8)}, &testdata.Frame{}
+	out := &testdata.Track{Frames: []*testdata.Frame{first, second}}
+
+	src.DeepCopyInto(out)
+
+	if diff := cmp.Diff(out, src); diff != "" {
+		t.Fatalf("DeepCopyInto() diff = %s", diff)
+	}
+	if out.Frames[0] != first || cap(first.Data) != 8 {
+		t.Error("the first frame of out, and its data, weren't reused")
+	}
+	if out.Frames[1] != nil {
+		t.Errorf("out.Frames[1] = %+v, want nil", out.Frames[1])
+	}
+	if out.Frames[2] == src.Frames[2] {
+		t.Error("out.Frames[2] is the frame of the source")
+	}
+
+	out.Frames[2].Data[0] = 'x'
+	if string(src.Frames[2].Data) != "c" {
+		t.Errorf("source modified through the copy: %q", src.Frames[2].Data)
+	}
+}
+
+func Test_reuseIntoSourceArrays(t *testing.T) {
+	src := &testdata.Track{Frames: []*testdata.Frame{{Data: []byte("a")}, {Data: []byte("b")}}}
+
+	out := *src
+	src.DeepCopyInto(&out)
+
+	if diff := cmp.Diff(&out, src); diff != "" {
+		t.Fatalf("DeepCopyInto() diff = %s", diff)
+	}
+	if &out.Frames[0] == &src.Frames[0] || out.Frames[0] == src.Frames[0] {
+		t.Error("the frames of the source were reused")
+	}
+
+	out.Frames[1].Data[0] = 'x'
+	out.Frames[0] = nil
+	if src.Frames[0] == nil || string(src.Frames[1].Data) != "b" {
+		t.Errorf("source modified through the copy: %+v", src.Frames)
+	}
+}
+
+func Benchmark_reuseInto(b *testing.B) {
+	src := &testdata.Frame{
+		Data:   make([]byte, 1024),
+		Bars:   []*testdata.Bar{{IntV: 1}, {IntV: 2}, {IntV: 3}},
+		Header: testdata.FrameHeader{Tags: []string{"a", "b", "c"}},
+	}
+
+	b.Run("reused", func(b *testing.B) {
+		b.ReportAllocs()
+		var out testdata.Frame
+		for i := 0; i < b.N; i++ {
+			src.DeepCopyInto(&out)
+		}
+	})
+	b.Run("new", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var out testdata.Frame
+			src.DeepCopyInto(&out)
+		}
+	})
+}
+
+func Benchmark_reuseIntoElements(b *testing.B) {
+	src := &testdata.Track{}
+	for i := 0; i < 16; i++ {
+		src.Frames = append(src.Frames, &testdata.Frame{Data: make([]byte, 256)})
+	}
+
+	b.Run("reused", func(b *testing.B) {
+		b.ReportAllocs()
+		var out testdata.Track
+		for i := 0; i < b.N; i++ {
+			src.DeepCopyInto(&out)
+		}
+	})
+	b.Run("new", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var out testdata.Track
+			src.DeepCopyInto(&out)
+		}
+	})
+}
+
+func Test_nilKinds(t *testing.T) {
+	n := 1
+	tests := []struct {
+		name string
+		src  testdata.NilKinds
+	}{
+		{name: "nil", src: testdata.NilKinds{}},
+		{name: "empty", src: testdata.NilKinds{
+			Slice:   []int{},
+			Map:     map[string]int{},
+			Structs: map[string]testdata.NilKindsValue{},
+			Keys:    map[testdata.NilKindsKey][]string{},
+		}},
+		{name: "set", src: testdata.NilKinds{
+			Slice:   []int{1},
+			Map:     map[string]int{"a": 1},
+			Pointer: &n,
+			Chan:    make(chan int, 1),
+			Func:    func() {},
+			Err:     errors.New("err"),
+			Structs: map[string]testdata.NilKindsValue{"a": {N: 1, Tags: []string{"t"}}, "b": {N: 2}},
+			Keys:    map[testdata.NilKindsKey][]string{{N: 1, Name: "a"}: {"v"}, {N: 2}: nil},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp := tt.src.DeepCopy()
+
+			src, dst := reflect.ValueOf(tt.src), reflect.ValueOf(cp)
+			for i := 0; i < src.NumField(); i++ {
+				name := src.Type().Field(i).Name
+				if src.Field(i).IsNil() != dst.Field(i).IsNil() {
+					t.Errorf("%s: source nil = %v, copy nil = %v", name, src.Field(i).IsNil(), dst.Field(i).IsNil())
+				}
+
+				switch src.Field(i).Kind() {
+				case reflect.Chan, reflect.Func:
+					// Compared by identity, a deep copy is never equal.
+				default:
+					if !reflect.DeepEqual(src.Field(i).Interface(), dst.Field(i).Interface()) {
+						t.Errorf("%s: copy %v differs from source %v", name, dst.Field(i), src.Field(i))
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_nilAsEmpty(t *testing.T) {
+	cp := testdata.NilAsEmpty{Nested: map[string][]string{"nil": nil}}.DeepCopy()
+
+	if cp.Slice == nil {
+		t.Error("cp.Slice is nil")
+	}
+	if cp.Map == nil {
+		t.Error("cp.Map is nil")
+	}
+	if v, ok := cp.Nested["nil"]; !ok || v == nil {
+		t.Errorf("cp.Nested[%q] = %v, %v, want empty, true", "nil", v, ok)
+	}
+
+	// Writing to the copied maps must not panic.
+	cp.Map["a"] = 1
+}
+
+var re = regexp.MustCompile(`generated by .*deep-copy.*; DO NOT EDIT.`)
+
+func normalizeComment(in []byte) []byte {
+	return re.ReplaceAll(bytes.TrimSpace(in), []byte("generated by deep-copy; DO NOT EDIT."))
+}
+
+const (
+	FooFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Foo
+func (o Foo) DeepCopy() Foo {
+	var cp Foo = o
+	if o.Map != nil {
+		cp.Map = make(map[string]*Bar, len(o.Map))
+		for k2, v2 := range o.Map {
+			var cp_Map_v2 *Bar
+			if v2 != nil {
+				cp_Map_v2 = new(Bar)
+				*cp_Map_v2 = *v2
+				if v2.Slice != nil {
+					cp_Map_v2.Slice = make([]string, len(v2.Slice))
+					copy(cp_Map_v2.Slice, v2.Slice)
+				}
+			}
+			cp.Map[k2] = cp_Map_v2
+		}
+	}
+	if o.ch != nil {
+		cp.ch = make(chan float32, cap(o.ch))
+	}
+	if o.baz.StringPointer != nil {
+		cp.baz.StringPointer = new(string)
+		*cp.baz.StringPointer = *o.baz.StringPointer
+	}
+	return cp
+}`
+	FooPointerFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of *Foo
+func (o *Foo) DeepCopy() *Foo {
+	var cp Foo = *o
+	if o.Map != nil {
+		cp.Map = make(map[string]*Bar, len(o.Map))
+		for k2, v2 := range o.Map {
+			var cp_Map_v2 *Bar
+			if v2 != nil {
+				cp_Map_v2 = new(Bar)
+				*cp_Map_v2 = *v2
+				if v2.Slice != nil {
+					cp_Map_v2.Slice = make([]string, len(v2.Slice))
+					copy(cp_Map_v2.Slice, v2.Slice)
+				}
+			}
+			cp.Map[k2] = cp_Map_v2
+		}
+	}
+	if o.ch != nil {
+		cp.ch = make(chan float32, cap(o.ch))
+	}
+	if o.baz.StringPointer != nil {
+		cp.baz.StringPointer = new(string)
+		*cp.baz.StringPointer = *o.baz.StringPointer
+	}
+	return &cp
+}`
+	FooPointerSkipSliceFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of *Foo
+func (o *Foo) DeepCopy() *Foo {
+	var cp Foo = *o
+	if o.Map != nil {
+		cp.Map = make(map[string]*Bar, len(o.Map))
+		for k2, v2 := range o.Map {
+			var cp_Map_v2 *Bar
+			if v2 != nil {
+				cp_Map_v2 = new(Bar)
+				*cp_Map_v2 = *v2
+			}
+			cp.Map[k2] = cp_Map_v2
+		}
+	}
+	if o.ch != nil {
+		cp.ch = make(chan float32, cap(o.ch))
+	}
+	if o.baz.StringPointer != nil {
+		cp.baz.StringPointer = new(string)
+		*cp.baz.StringPointer = *o.baz.StringPointer
+	}
+	return &cp
+}`
+	FooSkipMapFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Foo
+func (o Foo) DeepCopy() Foo {
+	var cp Foo = o
+	if o.Map != nil {
+		cp.Map = make(map[string]*Bar, len(o.Map))
+		for k2, v2 := range o.Map {
+			cp.Map[k2] = v2
+		}
+	}
+	if o.ch != nil {
+		cp.ch = make(chan float32, cap(o.ch))
+	}
+	if o.baz.StringPointer != nil {
+		cp.baz.StringPointer = new(string)
+		*cp.baz.StringPointer = *o.baz.StringPointer
+	}
+	return cp
+}`
+	AlphaPointer = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Alpha
+func (o Alpha) DeepCopy() Alpha {
+	var cp Alpha = o
+	if o.B != nil {
+		cp.B = o.B.DeepCopy()
+	}
+	cp.G = o.G.DeepCopy()
+	if o.D != nil {
+		retV := o.D.DeepCopy()
+		cp.D = &retV
+	}
+	if retV := o.E.DeepCopy(); retV != nil {
+		cp.E = *retV
+	}
+	return cp
+}`
+	SlicePointer = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of SlicePointer
+func (o SlicePointer) DeepCopy() SlicePointer {
+	var cp SlicePointer = o
+	if o != nil {
+		cp = make([]*int, len(o))
+		copy(cp, o)
+	}
+	return cp
+}`
+	FooAlphaSkips = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Foo
+func (o Foo) DeepCopy() Foo {
+	var cp Foo = o
+	if o.Map != nil {
+		cp.Map = make(map[string]*Bar, len(o.Map))
+		for k2, v2 := range o.Map {
+			cp.Map[k2] = v2
+		}
+	}
+	if o.baz.StringPointer != nil {
+		cp.baz.StringPointer = new(string)
+		*cp.baz.StringPointer = *o.baz.StringPointer
+	}
+	return cp
+}
+
+// DeepCopy generates a deep copy of Alpha
+func (o Alpha) DeepCopy() Alpha {
+	var cp Alpha = o
+	if o.B != nil {
+		cp.B = o.B.DeepCopy()
+	}
+	cp.G = o.G.DeepCopy()
+	return cp
+}`
+
+	Issue3SliceSimpleStruct = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of *I3WithSlice
+func (o *I3WithSlice) DeepCopy() *I3WithSlice {
+	var cp I3WithSlice = *o
+	if o.a != nil {
+		cp.a = make([]I3SimpleStruct, len(o.a))
+		copy(cp.a, o.a)
+	}
+	return &cp
+}`
+	Issue3MapSimpleStructKey = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of *I3WithMap
+func (o *I3WithMap) DeepCopy() *I3WithMap {
+	var cp I3WithMap = *o
+	if o.a != nil {
+		cp.a = make(map[I3SimpleStruct]string, len(o.a))
+		for k2, v2 := range o.a {
+			cp.a[k2] = v2
+		}
+	}
+	return &cp
+}`
+	Issue3MapSimpleStructVal = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of I3WithMapVal
+func (o I3WithMapVal) DeepCopy() I3WithMapVal {
+	var cp I3WithMapVal = o
+	if o.a != nil {
+		cp.a = make(map[string]I3SimpleStruct, len(o.a))
+		for k2, v2 := range o.a {
+			cp.a[k2] = v2
+		}
+	}
+	return cp
+}`
+
+	Issue7ShadowedMapVars = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of SomeStruct2
+func (o SomeStruct2) DeepCopy() SomeStruct2 {
+	var cp SomeStruct2 = o
+	if o.mapStruct != nil {
+		cp.mapStruct = make(map[string]SomeStruct, len(o.mapStruct))
+		for k2, v2 := range o.mapStruct {
+			var cp_mapStruct_v2 SomeStruct = v2
+			if v2.mapSlice != nil {
+				cp_mapStruct_v2.mapSlice = make(map[string][]string, len(v2.mapSlice))
+				for k4, v4 := range v2.mapSlice {
+					var cp_mapStruct_v2_mapSlice_v4 []string
+					if v4 != nil {
+						cp_mapStruct_v2_mapSlice_v4 = make([]string, len(v4))
+						copy(cp_mapStruct_v2_mapSlice_v4, v4)
+					}
+					cp_mapStruct_v2.mapSlice[k4] = cp_mapStruct_v2_mapSlice_v4
+				}
+			}
+			cp.mapStruct[k2] = cp_mapStruct_v2
+		}
+	}
+	return cp
+}`
+
+	Issue7ShadowedMapVars2 = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of SomeStruct
+func (o SomeStruct) DeepCopy() SomeStruct {
+	var cp SomeStruct = o
+	if o.mapSlice != nil {
+		cp.mapSlice = make(map[string][]string, len(o.mapSlice))
+		for k2, v2 := range o.mapSlice {
+			var cp_mapSlice_v2 []string
+			if v2 != nil {
+				cp_mapSlice_v2 = make([]string, len(v2))
+				copy(cp_mapSlice_v2, v2)
+			}
+			cp.mapSlice[k2] = cp_mapSlice_v2
+		}
+	}
+	return cp
+}
+
+// DeepCopy generates a deep copy of SomeStruct2
+func (o SomeStruct2) DeepCopy() SomeStruct2 {
+	var cp SomeStruct2 = o
+	if o.mapStruct != nil {
+		cp.mapStruct = make(map[string]SomeStruct, len(o.mapStruct))
+		for k2, v2 := range o.mapStruct {
+			var cp_mapStruct_v2 SomeStruct
+			cp_mapStruct_v2 = v2.DeepCopy()
+			cp.mapStruct[k2] = cp_mapStruct_v2
+		}
+	}
+	return cp
+}`
+
+	Issue10StructCH = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of StructCH
+func (o StructCH) DeepCopy() StructCH {
+	var cp StructCH = o
+	if o.Nested != nil {
+		cp.Nested = make([]StructNested, len(o.Nested))
+		copy(cp.Nested, o.Nested)
+		for i2 := range o.Nested {
+			if o.Nested[i2].B != nil {
+				cp.Nested[i2].B = new(int)
+				*cp.Nested[i2].B = *o.Nested[i2].B
+			}
+		}
+	}
+	return cp
+}`
+
+	PointerThatImplementsDeepcopy = `// generated by deep-copy; DO NOT EDIT.
+
+package somepkg
+
+// DeepCopy generates a deep copy of SomeStruct
+func (o SomeStruct) DeepCopy() SomeStruct {
+	var cp SomeStruct = o
+	if o.AnotherStruct != nil {
+		cp.AnotherStruct = o.AnotherStruct.DeepCopy()
+	}
+	return cp
+}`
+
+	Issue12NestedSlices = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of I12NestedSlices
+func (o I12NestedSlices) DeepCopy() I12NestedSlices {
+	var cp I12NestedSlices = o
+	if o.Slices != nil {
+		cp.Slices = make([][][]int, len(o.Slices))
+		copy(cp.Slices, o.Slices)
+		for i2 := range o.Slices {
+			if o.Slices[i2] != nil {
+				cp.Slices[i2] = make([][]int, len(o.Slices[i2]))
+				copy(cp.Slices[i2], o.Slices[i2])
+				for i3 := range o.Slices[i2] {
+					if o.Slices[i2][i3] != nil {
+						cp.Slices[i2][i3] = make([]int, len(o.Slices[i2][i3]))
+						copy(cp.Slices[i2][i3], o.Slices[i2][i3])
+					}
+				}
+			}
+		}
+	}
+	return cp
+}`
+
+	Issue12MapWithSliceValues = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of I12StructWithMapOfSlices
+func (o I12StructWithMapOfSlices) DeepCopy() I12StructWithMapOfSlices {
+	var cp I12StructWithMapOfSlices = o
+	if o.Sc1 != nil {
+		cp.Sc1 = make(map[string][]I12StructWithSlices, len(o.Sc1))
+		for k2, v2 := range o.Sc1 {
+			var cp_Sc1_v2 []I12StructWithSlices
+			if v2 != nil {
+				cp_Sc1_v2 = make([]I12StructWithSlices, len(v2))
+				copy(cp_Sc1_v2, v2)
+				for i3 := range v2 {
+					if v2[i3].Name != nil {
+						cp_Sc1_v2[i3].Name = make([]string, len(v2[i3].Name))
+						copy(cp_Sc1_v2[i3].Name, v2[i3].Name)
+					}
+				}
+			}
+			cp.Sc1[k2] = cp_Sc1_v2
+		}
+	}
+	return cp
+}`
+
+	I15ParentHasChildValueValueRecv = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of ParentHasChildValue
+func (o ParentHasChildValue) DeepCopy() ParentHasChildValue {
+	var cp ParentHasChildValue = o
+	cp.c = o.c.DeepCopy()
+	return cp
+}
+
+// DeepCopy generates a deep copy of Child
+func (o Child) DeepCopy() Child {
+	var cp Child = o
+	return cp
+}`
+
+	I15ParentHasChildPointerValueRecv = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of ParentHasChildPointer
+func (o ParentHasChildPointer) DeepCopy() ParentHasChildPointer {
+	var cp ParentHasChildPointer = o
+	if o.c != nil {
+		retV := o.c.DeepCopy()
+		cp.c = &retV
+	}
+	return cp
+}
+
+// DeepCopy generates a deep copy of Child
+func (o Child) DeepCopy() Child {
+	var cp Child = o
+	return cp
+}`
+
+	I15ParentHasChildValuePointerRecv = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of *ParentHasChildValue
+func (o *ParentHasChildValue) DeepCopy() *ParentHasChildValue {
+	var cp ParentHasChildValue = *o
+	if retV := o.c.DeepCopy(); retV != nil {
+		cp.c = *retV
+	}
+	return &cp
+}
+
+// DeepCopy generates a deep copy of *Child
+func (o *Child) DeepCopy() *Child {
+	var cp Child = *o
+	return &cp
+}`
+
+	I15ParentHasChildPointerPointerRecv = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of *ParentHasChildPointer
+func (o *ParentHasChildPointer) DeepCopy() *ParentHasChildPointer {
+	var cp ParentHasChildPointer = *o
+	if o.c != nil {
+		cp.c = o.c.DeepCopy()
+	}
+	return &cp
+}
+
+// DeepCopy generates a deep copy of *Child
+func (o *Child) DeepCopy() *Child {
+	var cp Child = *o
+	return &cp
+}`
+
+	Issue17MaxDepth = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of *Depth1
+func (o *Depth1) DeepCopy() *Depth1 {
+	var cp Depth1 = *o
+	if o.a1 != nil {
+		cp.a1 = new(Depth2)
+		*cp.a1 = *o.a1
+	}
+	if o.a2 != nil {
+		cp.a2 = new(Depth2)
+		*cp.a2 = *o.a2
+	}
+	return &cp
+}`
+	IntoSlicePointer = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopyInto generates a deep copy of *SlicePointer into out
+func (o *SlicePointer) DeepCopyInto(out *SlicePointer) {
+	*out = *o
+	if *o != nil {
+		*out = make([]*int, len(*o))
+		copy(*out, *o)
+		for i := range *o {
+			if (*o)[i] != nil {
+				(*out)[i] = new(int)
+				*(*out)[i] = *(*o)[i]
+			}
+		}
+	}
+}
+
+// DeepCopy generates a deep copy of SlicePointer
+func (o SlicePointer) DeepCopy() SlicePointer {
+	var cp SlicePointer
+	o.DeepCopyInto(&cp)
+	return cp
+}`
+
+	IntoMapPointer = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopyInto generates a deep copy of *MapPointer into out
+func (o *MapPointer) DeepCopyInto(out *MapPointer) {
+	*out = *o
+	if *o != nil {
+		*out = make(MapPointer, len(*o))
+		for k, v := range *o {
+			var out_v *int
+			if v != nil {
+				out_v = new(int)
+				*out_v = *v
+			}
+			(*out)[k] = out_v
+		}
+	}
+}
+
+// DeepCopy generates a deep copy of *MapPointer
+func (o *MapPointer) DeepCopy() *MapPointer {
+	var cp MapPointer
+	o.DeepCopyInto(&cp)
+	return &cp
+}`
+	SlicePointerPointerRecv = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of *SlicePointer
+func (o *SlicePointer) DeepCopy() *SlicePointer {
+	var cp SlicePointer = *o
+	if *o != nil {
+		cp = make([]*int, len(*o))
+		copy(cp, *o)
+		for i := range *o {
+			if (*o)[i] != nil {
+				cp[i] = new(int)
+				*cp[i] = *(*o)[i]
+			}
+		}
+	}
+	return &cp
+}`
+	SkipAllLogger = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Service
+func (o Service) DeepCopy() Service {
+	var cp Service = o
+	return cp
+}
+
+// DeepCopy generates a deep copy of Worker
+func (o Worker) DeepCopy() Worker {
+	var cp Worker = o
+	if o.Queue != nil {
+		cp.Queue = make(map[string]*int, len(o.Queue))
+		for k2, v2 := range o.Queue {
+			var cp_Queue_v2 *int
+			if v2 != nil {
+				cp_Queue_v2 = new(int)
+				*cp_Queue_v2 = *v2
+			}
+			cp.Queue[k2] = cp_Queue_v2
+		}
+	}
+	if o.Nested.Tags != nil {
+		cp.Nested.Tags = make([]string, len(o.Nested.Tags))
+		copy(cp.Nested.Tags, o.Nested.Tags)
+	}
+	return cp
+}`
+	TestsFixture = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Fixture
+func (o Fixture) DeepCopy() Fixture {
+	var cp Fixture = o
+	if o.Inputs != nil {
+		cp.Inputs = make([]string, len(o.Inputs))
+		copy(cp.Inputs, o.Inputs)
+	}
+	if o.Expected != nil {
+		cp.Expected = make(map[string]*Foo, len(o.Expected))
+		for k2, v2 := range o.Expected {
+			var cp_Expected_v2 *Foo
+			if v2 != nil {
+				retV := v2.DeepCopy()
+				cp_Expected_v2 = &retV
+			}
+			cp.Expected[k2] = cp_Expected_v2
+		}
+	}
+	return cp
+}`
+	InterfaceEmbeddedDeepCopy = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Drawing
+func (o Drawing) DeepCopy() Drawing {
+	var cp Drawing = o
+	if o.Main != nil {
+		cp.Main = o.Main.DeepCopy()
+	}
+	if o.Shapes != nil {
+		cp.Shapes = make([]Shape, len(o.Shapes))
+		copy(cp.Shapes, o.Shapes)
+		for i2 := range o.Shapes {
+			if o.Shapes[i2] != nil {
+				cp.Shapes[i2] = o.Shapes[i2].DeepCopy()
+			}
+		}
+	}
+	if o.Labels != nil {
+		cp.Labels = make(map[string]Named, len(o.Labels))
+		for k2, v2 := range o.Labels {
+			var cp_Labels_v2 Named
+			if v2 != nil {
+				cp_Labels_v2 = v2.DeepCopy().(Named)
+			}
+			cp.Labels[k2] = cp_Labels_v2
+		}
+	}
+	if o.Title != nil {
+		cp.Title = o.Title.DeepCopy().(Named)
+	}
+	return cp
+}`
+	GenShallow = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Shallow
+func (o Shallow) DeepCopy() Shallow {
+	var cp Shallow = o
+	if o.Items != nil {
+		cp.Items = make([]int, len(o.Items))
+		copy(cp.Items, o.Items)
+	}
+	return cp
+}
+
+// ShallowCopy generates a shallow copy of Shallow
+func (o Shallow) ShallowCopy() Shallow {
+	return o
+}
+
+// DeepCopy generates a deep copy of ShallowWithMethod
+func (o ShallowWithMethod) DeepCopy() ShallowWithMethod {
+	var cp ShallowWithMethod = o
+	if o.Items != nil {
+		cp.Items = make([]int, len(o.Items))
+		copy(cp.Items, o.Items)
+	}
+	return cp
+}`
+
+	GenShallowPointer = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of *Shallow
+func (o *Shallow) DeepCopy() *Shallow {
+	var cp Shallow = *o
+	if o.Items != nil {
+		cp.Items = make([]int, len(o.Items))
+		copy(cp.Items, o.Items)
+	}
+	return &cp
+}
+
+// ShallowCopy generates a shallow copy of *Shallow
+func (o *Shallow) ShallowCopy() *Shallow {
+	cp := *o
+	return &cp
+}`
+
+	GenShallowOutput = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of ShallowWithMethod
+func (o ShallowWithMethod) DeepCopy() ShallowWithMethod {
+	var cp ShallowWithMethod = o
+	if o.Items != nil {
+		cp.Items = make([]int, len(o.Items))
+		copy(cp.Items, o.Items)
+	}
+	return cp
+}
+
+// ShallowCopy generates a shallow copy of ShallowWithMethod
+func (o ShallowWithMethod) ShallowCopy() ShallowWithMethod {
+	return o
+}`
+	PtrListNilElements = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of PtrList
+func (o PtrList) DeepCopy() PtrList {
+	var cp PtrList = o
+	if o.Items != nil {
+		cp.Items = make([]*PtrItem, len(o.Items))
+		copy(cp.Items, o.Items)
+		for i2 := range o.Items {
+			if o.Items[i2] != nil {
+				cp.Items[i2] = new(PtrItem)
+				*cp.Items[i2] = *o.Items[i2]
+				if o.Items[i2].Tags != nil {
+					cp.Items[i2].Tags = make([]string, len(o.Items[i2].Tags))
+					copy(cp.Items[i2].Tags, o.Items[i2].Tags)
+				}
+			}
+		}
+	}
+	return cp
+}`
+	InterfaceSliceShared = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	"io"
+)
+
+// DeepCopy generates a deep copy of Readers
+func (o Readers) DeepCopy() Readers {
+	var cp Readers = o
+	if o.Sources != nil {
+		cp.Sources = make([]io.Reader, len(o.Sources))
+		copy(cp.Sources, o.Sources)
+	}
+	return cp
+}`
+	ChanMapValues = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of ChanMap
+func (o ChanMap) DeepCopy() ChanMap {
+	var cp ChanMap = o
+	if o.Chans != nil {
+		cp.Chans = make(map[string]chan int, len(o.Chans))
+		for k2, v2 := range o.Chans {
+			var cp_Chans_v2 chan int
+			if v2 != nil {
+				cp_Chans_v2 = make(chan int, cap(v2))
+			}
+			cp.Chans[k2] = cp_Chans_v2
+		}
+	}
+	return cp
+}`
+	NilKindsFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of NilKinds
+func (o NilKinds) DeepCopy() NilKinds {
+	var cp NilKinds = o
+	if o.Slice != nil {
+		cp.Slice = make([]int, len(o.Slice))
+		copy(cp.Slice, o.Slice)
+	}
+	if o.Map != nil {
+		cp.Map = make(map[string]int, len(o.Map))
+		for k2, v2 := range o.Map {
+			cp.Map[k2] = v2
+		}
+	}
+	if o.Pointer != nil {
+		cp.Pointer = new(int)
+		*cp.Pointer = *o.Pointer
+	}
+	if o.Chan != nil {
+		cp.Chan = make(chan int, cap(o.Chan))
+	}
+	if o.Structs != nil {
+		cp.Structs = make(map[string]NilKindsValue, len(o.Structs))
+		for k2, v2 := range o.Structs {
+			var cp_Structs_v2 NilKindsValue = v2
+			if v2.Tags != nil {
+				cp_Structs_v2.Tags = make([]string, len(v2.Tags))
+				copy(cp_Structs_v2.Tags, v2.Tags)
+			}
+			cp.Structs[k2] = cp_Structs_v2
+		}
+	}
+	if o.Keys != nil {
+		cp.Keys = make(map[NilKindsKey][]string, len(o.Keys))
+		for k2, v2 := range o.Keys {
+			var cp_Keys_v2 []string
+			if v2 != nil {
+				cp_Keys_v2 = make([]string, len(v2))
+				copy(cp_Keys_v2, v2)
+			}
+			cp.Keys[k2] = cp_Keys_v2
+		}
+	}
+	return cp
+}`
+	NestedGenerics = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	"github.com/globusdigital/deep-copy/testdata/generic"
+	"github.com/globusdigital/deep-copy/testdata/pointer_that_implements_deepcopy/anotherpkg"
+)
+
+// DeepCopy generates a deep copy of NestedGeneric
+func (o NestedGeneric) DeepCopy() NestedGeneric {
+	var cp NestedGeneric = o
+	if o.P.First.Items != nil {
+		cp.P.First.Items = make([]int, len(o.P.First.Items))
+		copy(cp.P.First.Items, o.P.First.Items)
+	}
+	if o.P.Second != nil {
+		cp.P.Second = o.P.Second.DeepCopy()
+	}
+	if o.P.Ptr != nil {
+		cp.P.Ptr = new(generic.Box[int])
+		*cp.P.Ptr = *o.P.Ptr
+		if o.P.Ptr.Items != nil {
+			cp.P.Ptr.Items = make([]int, len(o.P.Ptr.Items))
+			copy(cp.P.Ptr.Items, o.P.Ptr.Items)
+		}
+	}
+	if o.P.Values != nil {
+		cp.P.Values = make(map[string][]*anotherpkg.AnotherStruct, len(o.P.Values))
+		for k3, v3 := range o.P.Values {
+			var cp_P_Values_v3 []*anotherpkg.AnotherStruct
+			if v3 != nil {
+				cp_P_Values_v3 = make([]*anotherpkg.AnotherStruct, len(v3))
+				copy(cp_P_Values_v3, v3)
+				for i4 := range v3 {
+					if v3[i4] != nil {
+						cp_P_Values_v3[i4] = v3[i4].DeepCopy()
+					}
+				}
+			}
+			cp.P.Values[k3] = cp_P_Values_v3
+		}
+	}
+	if o.Pairs != nil {
+		cp.Pairs = make([]generic.Pair[generic.Box[*Foo], generic.Box[string]], len(o.Pairs))
+		copy(cp.Pairs, o.Pairs)
+		for i2 := range o.Pairs {
+			if o.Pairs[i2].First.Items != nil {
+				cp.Pairs[i2].First.Items = make([]*Foo, len(o.Pairs[i2].First.Items))
+				copy(cp.Pairs[i2].First.Items, o.Pairs[i2].First.Items)
+				for i5 := range o.Pairs[i2].First.Items {
+					if o.Pairs[i2].First.Items[i5] != nil {
+						retV := o.Pairs[i2].First.Items[i5].DeepCopy()
+						cp.Pairs[i2].First.Items[i5] = &retV
+					}
+				}
+			}
+			if o.Pairs[i2].Second.Items != nil {
+				cp.Pairs[i2].Second.Items = make([]string, len(o.Pairs[i2].Second.Items))
+				copy(cp.Pairs[i2].Second.Items, o.Pairs[i2].Second.Items)
+			}
+			if o.Pairs[i2].Ptr != nil {
+				cp.Pairs[i2].Ptr = new(generic.Box[*Foo])
+				*cp.Pairs[i2].Ptr = *o.Pairs[i2].Ptr
+				if o.Pairs[i2].Ptr.Items != nil {
+					cp.Pairs[i2].Ptr.Items = make([]*Foo, len(o.Pairs[i2].Ptr.Items))
+					copy(cp.Pairs[i2].Ptr.Items, o.Pairs[i2].Ptr.Items)
+					for i6 := range o.Pairs[i2].Ptr.Items {
+						if o.Pairs[i2].Ptr.Items[i6] != nil {
+							retV := o.Pairs[i2].Ptr.Items[i6].DeepCopy()
+							cp.Pairs[i2].Ptr.Items[i6] = &retV
+						}
+					}
+				}
+			}
+			if o.Pairs[i2].Values != nil {
+				cp.Pairs[i2].Values = make(map[string][]generic.Box[string], len(o.Pairs[i2].Values))
+				for k4, v4 := range o.Pairs[i2].Values {
+					var cp_Pairs_i2_Values_v4 []generic.Box[string]
+					if v4 != nil {
+						cp_Pairs_i2_Values_v4 = make([]generic.Box[string], len(v4))
+						copy(cp_Pairs_i2_Values_v4, v4)
+						for i5 := range v4 {
+							if v4[i5].Items != nil {
+								cp_Pairs_i2_Values_v4[i5].Items = make([]string, len(v4[i5].Items))
+								copy(cp_Pairs_i2_Values_v4[i5].Items, v4[i5].Items)
+							}
+						}
+					}
+					cp.Pairs[i2].Values[k4] = cp_Pairs_i2_Values_v4
+				}
+			}
+		}
+	}
+	return cp
+}`
+	NilMapAsEmpty = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of NilAsEmpty
+func (o NilAsEmpty) DeepCopy() NilAsEmpty {
+	var cp NilAsEmpty = o
+	if o.Slice != nil {
+		cp.Slice = make([]int, len(o.Slice))
+		copy(cp.Slice, o.Slice)
+	}
+	cp.Map = make(map[string]int, len(o.Map))
+	for k2, v2 := range o.Map {
+		cp.Map[k2] = v2
+	}
+	cp.Nested = make(map[string][]string, len(o.Nested))
+	for k2, v2 := range o.Nested {
+		var cp_Nested_v2 []string
+		if v2 != nil {
+			cp_Nested_v2 = make([]string, len(v2))
+			copy(cp_Nested_v2, v2)
+		}
+		cp.Nested[k2] = cp_Nested_v2
+	}
+	return cp
+}`
+
+	NilSliceAsEmpty = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of NilAsEmpty
+func (o NilAsEmpty) DeepCopy() NilAsEmpty {
+	var cp NilAsEmpty = o
+	cp.Slice = make([]int, len(o.Slice))
+	copy(cp.Slice, o.Slice)
+	if o.Map != nil {
+		cp.Map = make(map[string]int, len(o.Map))
+		for k2, v2 := range o.Map {
+			cp.Map[k2] = v2
+		}
+	}
+	if o.Nested != nil {
+		cp.Nested = make(map[string][]string, len(o.Nested))
+		for k2, v2 := range o.Nested {
+			var cp_Nested_v2 []string
+			cp_Nested_v2 = make([]string, len(v2))
+			copy(cp_Nested_v2, v2)
+			cp.Nested[k2] = cp_Nested_v2
+		}
+	}
+	return cp
+}`
+	GenericSet = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Set[T]
+func (o Set[T]) DeepCopy() Set[T] {
+	var cp Set[T] = o
+	if o != nil {
+		cp = make(Set[T], len(o))
+		for k := range o {
+			cp[k] = struct{}{}
+		}
+	}
+	return cp
+}`
+
+	GenericSetIntoPointer = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopyInto generates a deep copy of *Set[T] into out
+func (o *Set[T]) DeepCopyInto(out *Set[T]) {
+	*out = *o
+	if *o != nil {
+		*out = make(Set[T], len(*o))
+		for k := range *o {
+			(*out)[k] = struct{}{}
+		}
+	}
+}
+
+// DeepCopy generates a deep copy of *Set[T]
+func (o *Set[T]) DeepCopy() *Set[T] {
+	var cp Set[T]
+	o.DeepCopyInto(&cp)
+	return &cp
+}`
+	AnonymousNestedFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	"github.com/globusdigital/deep-copy/testdata/generic"
+	"github.com/globusdigital/deep-copy/testdata/pointer_that_implements_deepcopy/anotherpkg"
+)
+
+// DeepCopy generates a deep copy of AnonymousNested
+func (o AnonymousNested) DeepCopy() AnonymousNested {
+	var cp AnonymousNested = o
+	if o.Outer.Inner.Boxes != nil {
+		cp.Outer.Inner.Boxes = make([]generic.Box[string], len(o.Outer.Inner.Boxes))
+		copy(cp.Outer.Inner.Boxes, o.Outer.Inner.Boxes)
+		for i4 := range o.Outer.Inner.Boxes {
+			if o.Outer.Inner.Boxes[i4].Items != nil {
+				cp.Outer.Inner.Boxes[i4].Items = make([]string, len(o.Outer.Inner.Boxes[i4].Items))
+				copy(cp.Outer.Inner.Boxes[i4].Items, o.Outer.Inner.Boxes[i4].Items)
+			}
+		}
+	}
+	if o.Outer.Inner.Structs != nil {
+		cp.Outer.Inner.Structs = make([]*anotherpkg.AnotherStruct, len(o.Outer.Inner.Structs))
+		copy(cp.Outer.Inner.Structs, o.Outer.Inner.Structs)
+		for i4 := range o.Outer.Inner.Structs {
+			if o.Outer.Inner.Structs[i4] != nil {
+				cp.Outer.Inner.Structs[i4] = o.Outer.Inner.Structs[i4].DeepCopy()
+			}
+		}
+	}
+	if o.Outer.Inners != nil {
+		cp.Outer.Inners = make([]struct{ Boxes map[string][]generic.Box[int] }, len(o.Outer.Inners))
+		copy(cp.Outer.Inners, o.Outer.Inners)
+		for i3 := range o.Outer.Inners {
+			if o.Outer.Inners[i3].Boxes != nil {
+				cp.Outer.Inners[i3].Boxes = make(map[string][]generic.Box[int], len(o.Outer.Inners[i3].Boxes))
+				for k5, v5 := range o.Outer.Inners[i3].Boxes {
+					var cp_Outer_Inners_i3_Boxes_v5 []generic.Box[int]
+					if v5 != nil {
+						cp_Outer_Inners_i3_Boxes_v5 = make([]generic.Box[int], len(v5))
+						copy(cp_Outer_Inners_i3_Boxes_v5, v5)
+						for i6 := range v5 {
+							if v5[i6].Items != nil {
+								cp_Outer_Inners_i3_Boxes_v5[i6].Items = make([]int, len(v5[i6].Items))
+								copy(cp_Outer_Inners_i3_Boxes_v5[i6].Items, v5[i6].Items)
+							}
+						}
+					}
+					cp.Outer.Inners[i3].Boxes[k5] = cp_Outer_Inners_i3_Boxes_v5
+				}
+			}
+		}
+	}
+	return cp
+}`
+	PtrSliceFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of PtrSlice
+func (o PtrSlice) DeepCopy() PtrSlice {
+	var cp PtrSlice = o
+	if o.Items != nil {
+		cp.Items = new([]*PtrItem)
+		*cp.Items = *o.Items
+		if *o.Items != nil {
+			*cp.Items = make([]*PtrItem, len(*o.Items))
+			copy(*cp.Items, *o.Items)
+			for i3 := range *o.Items {
+				if (*o.Items)[i3] != nil {
+					(*cp.Items)[i3] = new(PtrItem)
+					*(*cp.Items)[i3] = *(*o.Items)[i3]
+					if (*o.Items)[i3].Tags != nil {
+						(*cp.Items)[i3].Tags = make([]string, len((*o.Items)[i3].Tags))
+						copy((*cp.Items)[i3].Tags, (*o.Items)[i3].Tags)
+					}
+				}
+			}
+		}
+	}
+	if o.Maps != nil {
+		cp.Maps = new(map[string]*PtrItem)
+		*cp.Maps = *o.Maps
+		if *o.Maps != nil {
+			*cp.Maps = make(map[string]*PtrItem, len(*o.Maps))
+			for k3, v3 := range *o.Maps {
+				var cp_Maps_v3 *PtrItem
+				if v3 != nil {
+					cp_Maps_v3 = new(PtrItem)
+					*cp_Maps_v3 = *v3
+					if v3.Tags != nil {
+						cp_Maps_v3.Tags = make([]string, len(v3.Tags))
+						copy(cp_Maps_v3.Tags, v3.Tags)
+					}
+				}
+				(*cp.Maps)[k3] = cp_Maps_v3
+			}
+		}
+	}
+	return cp
+}
+
+// DeepCopy generates a deep copy of PtrPtr
+func (o PtrPtr) DeepCopy() PtrPtr {
+	var cp PtrPtr = o
+	if o.Item != nil {
+		cp.Item = new(*PtrItem)
+		*cp.Item = *o.Item
+		if *o.Item != nil {
+			*cp.Item = new(PtrItem)
+			**cp.Item = **o.Item
+			if (*o.Item).Tags != nil {
+				(*cp.Item).Tags = make([]string, len((*o.Item).Tags))
+				copy((*cp.Item).Tags, (*o.Item).Tags)
+			}
+		}
+	}
+	return cp
+}`
+	FuncsOutPackage = `// generated by deep-copy; DO NOT EDIT.
+
+package copies
+
+import (
+	"github.com/globusdigital/deep-copy/testdata"
+)
+
+// DeepCopyTree generates a deep copy of testdata.Tree
+func DeepCopyTree(o testdata.Tree) testdata.Tree {
+	var cp testdata.Tree = o
+	if o.Root != nil {
+		retV := DeepCopyNode(*o.Root)
+		cp.Root = &retV
+	}
+	if o.Nodes != nil {
+		cp.Nodes = make([]testdata.Node, len(o.Nodes))
+		copy(cp.Nodes, o.Nodes)
+		for i2 := range o.Nodes {
+			cp.Nodes[i2] = DeepCopyNode(o.Nodes[i2])
+		}
+	}
+	if o.ByName != nil {
+		cp.ByName = make(map[string]*testdata.Node, len(o.ByName))
+		for k2, v2 := range o.ByName {
+			var cp_ByName_v2 *testdata.Node
+			if v2 != nil {
+				retV := DeepCopyNode(*v2)
+				cp_ByName_v2 = &retV
+			}
+			cp.ByName[k2] = cp_ByName_v2
+		}
+	}
+	return cp
+}
+
+// DeepCopyNode generates a deep copy of testdata.Node
+func DeepCopyNode(o testdata.Node) testdata.Node {
+	var cp testdata.Node = o
+	if o.Children != nil {
+		cp.Children = make([]*testdata.Node, len(o.Children))
+		copy(cp.Children, o.Children)
+		for i2 := range o.Children {
+			if o.Children[i2] != nil {
+				retV := DeepCopyNode(*o.Children[i2])
+				cp.Children[i2] = &retV
+			}
+		}
+	}
+	return cp
+}`
+
+	FuncsIntoPointer = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopyIntoTree generates a deep copy of *Tree into out
+func DeepCopyIntoTree(o *Tree, out *Tree) {
+	*out = *o
+	if o.Root != nil {
+		out.Root = new(Node)
+		DeepCopyIntoNode(o.Root, out.Root)
+	}
+	if o.Nodes != nil {
+		out.Nodes = make([]Node, len(o.Nodes))
+		copy(out.Nodes, o.Nodes)
+		for i2 := range o.Nodes {
+			DeepCopyIntoNode(&o.Nodes[i2], &out.Nodes[i2])
+		}
+	}
+	if o.ByName != nil {
+		out.ByName = make(map[string]*Node, len(o.ByName))
+		for k2, v2 := range o.ByName {
+			var out_ByName_v2 *Node
+			if v2 != nil {
+				out_ByName_v2 = new(Node)
+				DeepCopyIntoNode(v2, out_ByName_v2)
+			}
+			out.ByName[k2] = out_ByName_v2
+		}
+	}
+	if o.hidden != nil {
+		out.hidden = make([]int, len(o.hidden))
+		copy(out.hidden, o.hidden)
+	}
+}
+
+// DeepCopyTree generates a deep copy of *Tree
+func DeepCopyTree(o *Tree) *Tree {
+	var cp Tree
+	DeepCopyIntoTree(o, &cp)
+	return &cp
+}
+
+// ShallowCopyTree generates a shallow copy of *Tree
+func ShallowCopyTree(o *Tree) *Tree {
+	cp := *o
+	return &cp
+}
+
+// DeepCopyIntoNode generates a deep copy of *Node into out
+func DeepCopyIntoNode(o *Node, out *Node) {
+	*out = *o
+	if o.Children != nil {
+		out.Children = make([]*Node, len(o.Children))
+		copy(out.Children, o.Children)
+		for i2 := range o.Children {
+			if o.Children[i2] != nil {
+				out.Children[i2] = new(Node)
+				DeepCopyIntoNode(o.Children[i2], out.Children[i2])
+			}
+		}
+	}
+}
+
+// DeepCopyNode generates a deep copy of *Node
+func DeepCopyNode(o *Node) *Node {
+	var cp Node
+	DeepCopyIntoNode(o, &cp)
+	return &cp
+}
+
+// ShallowCopyNode generates a shallow copy of *Node
+func ShallowCopyNode(o *Node) *Node {
+	cp := *o
+	return &cp
+}
+
+// DeepCopyIntoSet generates a deep copy of *Set[T] into out
+func DeepCopyIntoSet[T comparable](o *Set[T], out *Set[T]) {
+	*out = *o
+	if *o != nil {
+		*out = make(Set[T], len(*o))
+		for k := range *o {
+			(*out)[k] = struct{}{}
+		}
+	}
+}
+
+// DeepCopySet generates a deep copy of *Set[T]
+func DeepCopySet[T comparable](o *Set[T]) *Set[T] {
+	var cp Set[T]
+	DeepCopyIntoSet(o, &cp)
+	return &cp
+}
+
+// ShallowCopySet generates a shallow copy of *Set[T]
+func ShallowCopySet[T comparable](o *Set[T]) *Set[T] {
+	cp := *o
+	return &cp
+}`
+	EventLogImpls = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of EventLog
+func (o EventLog) DeepCopy() EventLog {
+	var cp EventLog = o
+	if o.Events != nil {
+		cp.Events = make(map[string][]Event, len(o.Events))
+		for k2, v2 := range o.Events {
+			var cp_Events_v2 []Event
+			if v2 != nil {
+				cp_Events_v2 = make([]Event, len(v2))
+				copy(cp_Events_v2, v2)
+				for i3 := range v2 {
+					switch impl4 := v2[i3].(type) {
+					case ClickEvent:
+						var cp_Events_v2_i3_impl4 ClickEvent = impl4
+						if impl4.Tags != nil {
+							cp_Events_v2_i3_impl4.Tags = make([]string, len(impl4.Tags))
+							copy(cp_Events_v2_i3_impl4.Tags, impl4.Tags)
+						}
+						cp_Events_v2[i3] = cp_Events_v2_i3_impl4
+					case *KeyEvent:
+						var cp_Events_v2_i3_impl4 *KeyEvent
+						if impl4 != nil {
+							cp_Events_v2_i3_impl4 = new(KeyEvent)
+							*cp_Events_v2_i3_impl4 = *impl4
+							if impl4.Keys != nil {
+								cp_Events_v2_i3_impl4.Keys = make(map[string]int, len(impl4.Keys))
+								for k7, v7 := range impl4.Keys {
+									cp_Events_v2_i3_impl4.Keys[k7] = v7
+								}
+							}
+						}
+						cp_Events_v2[i3] = cp_Events_v2_i3_impl4
+					}
+				}
+			}
+			cp.Events[k2] = cp_Events_v2
+		}
+	}
+	switch impl2 := o.Last.(type) {
+	case ClickEvent:
+		var cp_Last_impl2 ClickEvent = impl2
+		if impl2.Tags != nil {
+			cp_Last_impl2.Tags = make([]string, len(impl2.Tags))
+			copy(cp_Last_impl2.Tags, impl2.Tags)
+		}
+		cp.Last = cp_Last_impl2
+	case *KeyEvent:
+		var cp_Last_impl2 *KeyEvent
+		if impl2 != nil {
+			cp_Last_impl2 = new(KeyEvent)
+			*cp_Last_impl2 = *impl2
+			if impl2.Keys != nil {
+				cp_Last_impl2.Keys = make(map[string]int, len(impl2.Keys))
+				for k5, v5 := range impl2.Keys {
+					cp_Last_impl2.Keys[k5] = v5
+				}
+			}
+		}
+		cp.Last = cp_Last_impl2
+	}
+	return cp
+}`
+	ExternalUnexportedFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of ExternalUnexported
+func (o ExternalUnexported) DeepCopy() ExternalUnexported {
+	var cp ExternalUnexported = o
+	if o.External.Exported != nil {
+		cp.External.Exported = make([]int, len(o.External.Exported))
+		copy(cp.External.Exported, o.External.Exported)
+	}
+	return cp
+}`
+	DropChanFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of DropChan
+func (o DropChan) DeepCopy() DropChan {
+	var cp DropChan = o
+	cp.Done = nil
+	if o.Queues != nil {
+		cp.Queues = make([]chan int, len(o.Queues))
+		copy(cp.Queues, o.Queues)
+		for i2 := range o.Queues {
+			cp.Queues[i2] = nil
+		}
+	}
+	if o.ByName != nil {
+		cp.ByName = make(map[string]chan int, len(o.ByName))
+		for k2 := range o.ByName {
+			cp.ByName[k2] = nil
+		}
+	}
+	return cp
+}`
+
+	KeepChanFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of DropChan
+func (o DropChan) DeepCopy() DropChan {
+	var cp DropChan = o
+	if o.Done != nil {
+		cp.Done = make(chan struct{}, cap(o.Done))
+	}
+	if o.Queues != nil {
+		cp.Queues = make([]chan int, len(o.Queues))
+		copy(cp.Queues, o.Queues)
+		for i2 := range o.Queues {
+			if o.Queues[i2] != nil {
+				cp.Queues[i2] = make(chan int, cap(o.Queues[i2]))
+			}
+		}
+	}
+	if o.ByName != nil {
+		cp.ByName = make(map[string]chan int, len(o.ByName))
+		for k2, v2 := range o.ByName {
+			var cp_ByName_v2 chan int
+			if v2 != nil {
+				cp_ByName_v2 = make(chan int, cap(v2))
+			}
+			cp.ByName[k2] = cp_ByName_v2
+		}
+	}
+	return cp
+}`
+	VendoredFile = `// generated by deep-copy; DO NOT EDIT.
+
+package vendored
+
+import (
+	dep "example.com/go-dep"
+)
+
+// DeepCopy generates a deep copy of Vendored
+func (o Vendored) DeepCopy() Vendored {
+	var cp Vendored = o
+	if o.Item.Tags != nil {
+		cp.Item.Tags = make([]string, len(o.Item.Tags))
+		copy(cp.Item.Tags, o.Item.Tags)
+	}
+	if o.Items != nil {
+		cp.Items = make(map[string][]*dep.Item, len(o.Items))
+		for k2, v2 := range o.Items {
+			var cp_Items_v2 []*dep.Item
+			if v2 != nil {
+				cp_Items_v2 = make([]*dep.Item, len(v2))
+				copy(cp_Items_v2, v2)
+				for i3 := range v2 {
+					if v2[i3] != nil {
+						cp_Items_v2[i3] = new(dep.Item)
+						*cp_Items_v2[i3] = *v2[i3]
+						if v2[i3].Tags != nil {
+							cp_Items_v2[i3].Tags = make([]string, len(v2[i3].Tags))
+							copy(cp_Items_v2[i3].Tags, v2[i3].Tags)
+						}
+					}
+				}
+			}
+			cp.Items[k2] = cp_Items_v2
+		}
+	}
+	return cp
+}`
+	TaggedFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	"github.com/globusdigital/deep-copy/testdata/generic"
+)
+
+// DeepCopy generates a deep copy of Tagged
+func (o Tagged) DeepCopy() Tagged {
+	var cp Tagged = o
+	cp.ID = 0
+	cp.Name = ""
+	cp.Active = false
+	cp.Stamp = Baz{}
+	cp.Box = generic.Box[int]{}
+	cp.Cache = nil
+	cp.Index = nil
+	if o.Copied != nil {
+		cp.Copied = make([]int, len(o.Copied))
+		copy(cp.Copied, o.Copied)
+	}
+	if o.Ignored != nil {
+		cp.Ignored = make(map[string]string, len(o.Ignored))
+		for k2, v2 := range o.Ignored {
+			cp.Ignored[k2] = v2
+		}
+	}
+	return cp
+}`
+	MapArrayFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of MapArray
+func (o MapArray) DeepCopy() MapArray {
+	var cp MapArray = o
+	if o.Items != nil {
+		cp.Items = make(map[string][3]ArrayItem, len(o.Items))
+		for k2, v2 := range o.Items {
+			var cp_Items_v2 [3]ArrayItem = v2
+			for i3 := range v2 {
+				cp_Items_v2[i3] = v2[i3].DeepCopy()
+			}
+			cp.Items[k2] = cp_Items_v2
+		}
+	}
+	for i2 := range o.Grid {
+		if o.Grid[i2] != nil {
+			cp.Grid[i2] = make([]int, len(o.Grid[i2]))
+			copy(cp.Grid[i2], o.Grid[i2])
+		}
+	}
+	return cp
+}`
+	LicensedChild = `// Copyright 2020 Globus
+//
+// Licensed under MIT.
+
+// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Child
+func (o Child) DeepCopy() Child {
+	var cp Child = o
+	return cp
+}`
+	GenericTreeFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of GenericTree[T]
+func (o GenericTree[T]) DeepCopy() GenericTree[T] {
+	var cp GenericTree[T] = o
+	if o.Children != nil {
+		cp.Children = make([]*GenericTree[T], len(o.Children))
+		copy(cp.Children, o.Children)
+		for i2 := range o.Children {
+			if o.Children[i2] != nil {
+				retV := o.Children[i2].DeepCopy()
+				cp.Children[i2] = &retV
+			}
+		}
+	}
+	return cp
+}`
+	FuncFieldsFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	"io"
+)
+
+// DeepCopy generates a deep copy of FuncFields
+func (o FuncFields) DeepCopy() FuncFields {
+	var cp FuncFields = o
+	if o.Handlers != nil {
+		cp.Handlers = make(map[string]func(io.Writer) []byte, len(o.Handlers))
+		for k2, v2 := range o.Handlers {
+			cp.Handlers[k2] = v2
+		}
+	}
+	if o.Hooks != nil {
+		cp.Hooks = make([]func(*FuncFields), len(o.Hooks))
+		copy(cp.Hooks, o.Hooks)
+	}
+	return cp
+}`
+	ValidatorsFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Validators
+func (o Validators) DeepCopy() Validators {
+	var cp Validators = o
+	if o.Checks != nil {
+		cp.Checks = make([]func(x int) (bool, error), len(o.Checks))
+		copy(cp.Checks, o.Checks)
+	}
+	return cp
+}`
+	AllocatedPointer = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of *Allocated
+func (o *Allocated) DeepCopy() *Allocated {
+	var cp Allocated = *o
+	if o.Items != nil {
+		cp.Items = make([]*PtrItem, len(o.Items))
+		copy(cp.Items, o.Items)
+		for i2 := range o.Items {
+			if o.Items[i2] != nil {
+				cp.Items[i2] = new(PtrItem)
+				*cp.Items[i2] = *o.Items[i2]
+				if o.Items[i2].Tags != nil {
+					cp.Items[i2].Tags = make([]string, len(o.Items[i2].Tags))
+					copy(cp.Items[i2].Tags, o.Items[i2].Tags)
+				}
+			}
+		}
+	}
+	if o.Index != nil {
+		cp.Index = make(map[string][]int, len(o.Index))
+		for k2, v2 := range o.Index {
+			var cp_Index_v2 []int
+			if v2 != nil {
+				cp_Index_v2 = make([]int, len(v2))
+				copy(cp_Index_v2, v2)
+			}
+			cp.Index[k2] = cp_Index_v2
+		}
+	}
+	if o.Child != nil {
+		cp.Child = o.Child.DeepCopy()
+	}
+	if o.Values != nil {
+		cp.Values = make([]Allocated, len(o.Values))
+		copy(cp.Values, o.Values)
+		for i2 := range o.Values {
+			if retV := o.Values[i2].DeepCopy(); retV != nil {
+				cp.Values[i2] = *retV
+			}
+		}
+	}
+	return &cp
+}
+
+// DeepCopyWith generates a deep copy of *Allocated, allocated by the allocator
+func (o *Allocated) DeepCopyWith(allocator AllocatedAllocator) *Allocated {
+	cp := allocator.NewAllocated()
+	*cp = *o
+	if o.Items != nil {
+		cp.Items = allocator.MakePtrItemPtrSlice(len(o.Items))
+		copy(cp.Items, o.Items)
+		for i2 := range o.Items {
+			if o.Items[i2] != nil {
+				cp.Items[i2] = allocator.NewPtrItem()
+				*cp.Items[i2] = *o.Items[i2]
+				if o.Items[i2].Tags != nil {
+					cp.Items[i2].Tags = allocator.MakeStringSlice(len(o.Items[i2].Tags))
+					copy(cp.Items[i2].Tags, o.Items[i2].Tags)
+				}
+			}
+		}
+	}
+	if o.Index != nil {
+		cp.Index = allocator.MakeStringToIntSliceMap(len(o.Index))
+		for k2, v2 := range o.Index {
+			var cp_Index_v2 []int
+			if v2 != nil {
+				cp_Index_v2 = allocator.MakeIntSlice(len(v2))
+				copy(cp_Index_v2, v2)
+			}
+			cp.Index[k2] = cp_Index_v2
+		}
+	}
+	if o.Child != nil {
+		cp.Child = o.Child.DeepCopyWith(allocator)
+	}
+	if o.Values != nil {
+		cp.Values = allocator.MakeAllocatedSlice(len(o.Values))
+		copy(cp.Values, o.Values)
+		for i2 := range o.Values {
+			if retV := o.Values[i2].DeepCopyWith(allocator); retV != nil {
+				cp.Values[i2] = *retV
+			}
+		}
+	}
+	return cp
+}
+
+// AllocatedAllocator allocates the values copied by Allocated.DeepCopyWith
+type AllocatedAllocator interface {
+	MakeAllocatedSlice(len int) []Allocated
+	MakeIntSlice(len int) []int
+	MakePtrItemPtrSlice(len int) []*PtrItem
+	MakeStringSlice(len int) []string
+	MakeStringToIntSliceMap(size int) map[string][]int
+	NewAllocated() *Allocated
+	NewPtrItem() *PtrItem
+}`
+	HandlerChainImpls = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	"github.com/globusdigital/deep-copy/testdata/handlers"
+	"github.com/globusdigital/deep-copy/testdata/handlers/loggers"
+)
+
+// DeepCopy generates a deep copy of HandlerChain
+func (o HandlerChain) DeepCopy() HandlerChain {
+	var cp HandlerChain = o
+	if o.Handlers != nil {
+		cp.Handlers = make([]handlers.Handler, len(o.Handlers))
+		copy(cp.Handlers, o.Handlers)
+		for i2 := range o.Handlers {
+			switch impl3 := o.Handlers[i2].(type) {
+			case handlers.Mux:
+				var cp_Handlers_i2_impl3 handlers.Mux = impl3
+				if impl3.Routes != nil {
+					cp_Handlers_i2_impl3.Routes = make(map[string]string, len(impl3.Routes))
+					for k5, v5 := range impl3.Routes {
+						cp_Handlers_i2_impl3.Routes[k5] = v5
+					}
+				}
+				cp.Handlers[i2] = cp_Handlers_i2_impl3
+			case *loggers.Logger:
+				var cp_Handlers_i2_impl3 *loggers.Logger
+				if impl3 != nil {
+					cp_Handlers_i2_impl3 = impl3.DeepCopy()
+				}
+				cp.Handlers[i2] = cp_Handlers_i2_impl3
+			}
+		}
+	}
+	return cp
+}`
+	ScheduleFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	"time"
+)
+
+// DeepCopy generates a deep copy of Schedule
+func (o Schedule) DeepCopy() Schedule {
+	var cp Schedule = o
+	if o.Dates != nil {
+		cp.Dates = make([]time.Time, len(o.Dates))
+		copy(cp.Dates, o.Dates)
+	}
+	if o.Due != nil {
+		cp.Due = make(map[string]time.Time, len(o.Due))
+		for k2, v2 := range o.Due {
+			cp.Due[k2] = v2
+		}
+	}
+	return cp
+}`
+	RecordFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	"github.com/globusdigital/deep-copy/testdata/driver"
+)
+
+// DeepCopy generates a deep copy of Record
+func (o Record) DeepCopy() Record {
+	var cp Record = o
+	if o.Tags != nil {
+		cp.Tags = make([]string, len(o.Tags))
+		copy(cp.Tags, o.Tags)
+	}
+	if o.Options != nil {
+		cp.Options = new(driver.Options)
+		*cp.Options = *o.Options
+		if o.Options.Params != nil {
+			cp.Options.Params = make(map[string]string, len(o.Options.Params))
+			for k4, v4 := range o.Options.Params {
+				cp.Options.Params[k4] = v4
+			}
+		}
+	}
+	return cp
+}`
+	ItemIndexPointerFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of *ItemIndex
+func (o *ItemIndex) DeepCopy() *ItemIndex {
+	var cp ItemIndex = *o
+	if o.Items != nil {
+		cp.Items = make(map[string]*ArrayItem, len(o.Items))
+		for k2, v2 := range o.Items {
+			var cp_Items_v2 *ArrayItem
+			if v2 != nil {
+				retV := v2.DeepCopy()
+				cp_Items_v2 = &retV
+			}
+			cp.Items[k2] = cp_Items_v2
+		}
+	}
+	return &cp
+}`
+	CounterFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of *Counter
+func (o *Counter) DeepCopy() *Counter {
+	var cp Counter
+	cp.Counts = o.Counts
+	cp.Stats.Hits = o.Stats.Hits
+	cp.Stats.Name = o.Stats.Name
+	if o.Counts != nil {
+		cp.Counts = make(map[string]int, len(o.Counts))
+		for k2, v2 := range o.Counts {
+			cp.Counts[k2] = v2
+		}
+	}
+	if o.Stats.Hits != nil {
+		cp.Stats.Hits = make([]int, len(o.Stats.Hits))
+		copy(cp.Stats.Hits, o.Stats.Hits)
+	}
+	return &cp
+}`
+
+	CounterIntoFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopyInto generates a deep copy of *Counter into out
+func (o *Counter) DeepCopyInto(out *Counter) {
+	out.Counts = o.Counts
+	out.Stats.Hits = o.Stats.Hits
+	out.Stats.Name = o.Stats.Name
+	if o.Counts != nil {
+		out.Counts = make(map[string]int, len(o.Counts))
+		for k2, v2 := range o.Counts {
+			out.Counts[k2] = v2
+		}
+	}
+	if o.Stats.Hits != nil {
+		out.Stats.Hits = make([]int, len(o.Stats.Hits))
+		copy(out.Stats.Hits, o.Stats.Hits)
+	}
+}
+
+// DeepCopy generates a deep copy of *Counter
+func (o *Counter) DeepCopy() *Counter {
+	var cp Counter
+	o.DeepCopyInto(&cp)
+	return &cp
+}`
+	KidPointerFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of *Kid
+func (o *Kid) DeepCopy() *Kid {
+	var cp Kid = *o
+	if o.Kids != nil {
+		cp.Kids = make([]Kid, len(o.Kids))
+		copy(cp.Kids, o.Kids)
+		for i2 := range o.Kids {
+			if retV := o.Kids[i2].DeepCopy(); retV != nil {
+				cp.Kids[i2] = *retV
+			}
+		}
+	}
+	return &cp
+}`
+	RecordAliasFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	dbdriver "github.com/globusdigital/deep-copy/testdata/driver"
+)
+
+// DeepCopy generates a deep copy of Record
+func (o Record) DeepCopy() Record {
+	var cp Record = o
+	if o.Tags != nil {
+		cp.Tags = make([]string, len(o.Tags))
+		copy(cp.Tags, o.Tags)
+	}
+	if o.Options != nil {
+		cp.Options = new(dbdriver.Options)
+		*cp.Options = *o.Options
+		if o.Options.Params != nil {
+			cp.Options.Params = make(map[string]string, len(o.Options.Params))
+			for k4, v4 := range o.Options.Params {
+				cp.Options.Params[k4] = v4
+			}
+		}
+	}
+	return cp
+}`
+	DirectedFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Directed
+func (o Directed) DeepCopy() Directed {
+	var cp Directed = o
+	if o.Both != nil {
+		cp.Both = make(chan int, cap(o.Both))
+	}
+	return cp
+}`
+
+	DirectedDropFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Directed
+func (o Directed) DeepCopy() Directed {
+	var cp Directed = o
+	cp.In = nil
+	cp.Out = nil
+	cp.Both = nil
+	return cp
+}`
+	NullableFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	"database/sql"
+)
+
+// DeepCopy generates a deep copy of Nullable
+func (o Nullable) DeepCopy() Nullable {
+	var cp Nullable = o
+	if o.Aliases != nil {
+		cp.Aliases = make([]sql.NullString, len(o.Aliases))
+		copy(cp.Aliases, o.Aliases)
+	}
+	if o.Scores != nil {
+		cp.Scores = make(map[string]sql.NullFloat64, len(o.Scores))
+		for k2, v2 := range o.Scores {
+			cp.Scores[k2] = v2
+		}
+	}
+	if o.Parent != nil {
+		cp.Parent = new(sql.NullTime)
+		*cp.Parent = *o.Parent
+	}
+	return cp
+}`
+	EmbeddedExternalFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of EmbeddedExternal
+func (o EmbeddedExternal) DeepCopy() EmbeddedExternal {
+	var cp EmbeddedExternal = o
+	if o.WithUnexported.Exported != nil {
+		cp.WithUnexported.Exported = make([]int, len(o.WithUnexported.Exported))
+		copy(cp.WithUnexported.Exported, o.WithUnexported.Exported)
+	}
+	return cp
+}`
+	SameNameFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	samename "github.com/globusdigital/deep-copy/testdata/samename"
+)
+
+// DeepCopy generates a deep copy of SameName
+func (o SameName) DeepCopy() SameName {
+	var cp SameName = o
+	if o.Inner != nil {
+		cp.Inner = new(samename.Inner)
+		*cp.Inner = *o.Inner
+		if o.Inner.Values != nil {
+			cp.Inner.Values = make([]int, len(o.Inner.Values))
+			copy(cp.Inner.Values, o.Inner.Values)
+		}
+	}
+	if o.Local != nil {
+		retV := o.Local.DeepCopy()
+		cp.Local = &retV
+	}
+	return cp
+}`
+	EventRefImpls = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of EventRef
+func (o EventRef) DeepCopy() EventRef {
+	var cp EventRef = o
+	if o.Event != nil {
+		cp.Event = new(Event)
+		*cp.Event = *o.Event
+		switch impl3 := (*o.Event).(type) {
+		case ClickEvent:
+			var cp_Event_impl3 ClickEvent = impl3
+			if impl3.Tags != nil {
+				cp_Event_impl3.Tags = make([]string, len(impl3.Tags))
+				copy(cp_Event_impl3.Tags, impl3.Tags)
+			}
+			*cp.Event = cp_Event_impl3
+		case *KeyEvent:
+			var cp_Event_impl3 *KeyEvent
+			if impl3 != nil {
+				cp_Event_impl3 = new(KeyEvent)
+				*cp_Event_impl3 = *impl3
+				if impl3.Keys != nil {
+					cp_Event_impl3.Keys = make(map[string]int, len(impl3.Keys))
+					for k6, v6 := range impl3.Keys {
+						cp_Event_impl3.Keys[k6] = v6
+					}
+				}
+			}
+			*cp.Event = cp_Event_impl3
+		}
+	}
+	return cp
+}`
+
+	ShapeRefFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of ShapeRef
+func (o ShapeRef) DeepCopy() ShapeRef {
+	var cp ShapeRef = o
+	if o.Shape != nil {
+		cp.Shape = new(Shape)
+		*cp.Shape = *o.Shape
+		if *o.Shape != nil {
+			*cp.Shape = (*o.Shape).DeepCopy()
+		}
+	}
+	return cp
+}`
+	ItemArraysPointerFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of *ItemArrays
+func (o *ItemArrays) DeepCopy() *ItemArrays {
+	var cp ItemArrays = *o
+	for i2 := range o.Items {
+		if o.Items[i2] != nil {
+			retV := o.Items[i2].DeepCopy()
+			cp.Items[i2] = &retV
+		}
+	}
+	for i2 := range o.Labels {
+		if retV := o.Labels[i2].DeepCopy(); retV != nil {
+			cp.Labels[i2] = *retV
+		}
+	}
+	return &cp
+}`
+	MyTimeFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of MyTime
+func (o MyTime) DeepCopy() MyTime {
+	var cp MyTime = o
+	if o.Zones != nil {
+		cp.Zones = make([]string, len(o.Zones))
+		copy(cp.Zones, o.Zones)
+	}
+	return cp
+}`
+	InventoryFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	"github.com/globusdigital/deep-copy/testdata/containers"
+)
+
+// DeepCopy generates a deep copy of Inventory
+func (o Inventory) DeepCopy() Inventory {
+	var cp Inventory = o
+	if o.Stock != nil {
+		cp.Stock = make(containers.OrderedMap[string, []int], len(o.Stock))
+		for k2, v2 := range o.Stock {
+			var cp_Stock_v2 []int
+			if v2 != nil {
+				cp_Stock_v2 = make([]int, len(v2))
+				copy(cp_Stock_v2, v2)
+			}
+			cp.Stock[k2] = cp_Stock_v2
+		}
+	}
+	if o.Owners != nil {
+		cp.Owners = make(containers.OrderedMap[string, *Kid], len(o.Owners))
+		for k2, v2 := range o.Owners {
+			var cp_Owners_v2 *Kid
+			if v2 != nil {
+				retV := v2.DeepCopy()
+				cp_Owners_v2 = &retV
+			}
+			cp.Owners[k2] = cp_Owners_v2
+		}
+	}
+	cp.Names = o.Names.DeepCopy()
+	if o.Lists != nil {
+		cp.Lists = make([]containers.List[*Foo], len(o.Lists))
+		copy(cp.Lists, o.Lists)
+		for i2 := range o.Lists {
+			cp.Lists[i2] = o.Lists[i2].DeepCopy()
+		}
+	}
+	return cp
+}`
+	KeyedFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Keyed
+func (o Keyed) DeepCopy() Keyed {
+	var cp Keyed = o
+	if o.ByKey != nil {
+		cp.ByKey = make(map[CompositeKey]string, len(o.ByKey))
+		for k2, v2 := range o.ByKey {
+			cp.ByKey[k2] = v2
+		}
+	}
+	if o.ByOwner != nil {
+		cp.ByOwner = make(map[*Kid][]int, len(o.ByOwner))
+		for k2, v2 := range o.ByOwner {
+			var cp_ByOwner_v2 []int
+			if v2 != nil {
+				cp_ByOwner_v2 = make([]int, len(v2))
+				copy(cp_ByOwner_v2, v2)
+			}
+			cp.ByOwner[k2] = cp_ByOwner_v2
+		}
+	}
+	return cp
+}`
+
+	KeyedDeepKeysFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Keyed
+func (o Keyed) DeepCopy() Keyed {
+	var cp Keyed = o
+	if o.ByKey != nil {
+		cp.ByKey = make(map[CompositeKey]string, len(o.ByKey))
+		for k2, v2 := range o.ByKey {
+			var cp_ByKey_k2 CompositeKey = k2
+			if k2.Owner != nil {
+				retV := k2.Owner.DeepCopy()
+				cp_ByKey_k2.Owner = &retV
+			}
+			cp.ByKey[cp_ByKey_k2] = v2
+		}
+	}
+	if o.ByOwner != nil {
+		cp.ByOwner = make(map[*Kid][]int, len(o.ByOwner))
+		for k2, v2 := range o.ByOwner {
+			var cp_ByOwner_k2 *Kid
+			if k2 != nil {
+				retV := k2.DeepCopy()
+				cp_ByOwner_k2 = &retV
+			}
+			var cp_ByOwner_v2 []int
+			if v2 != nil {
+				cp_ByOwner_v2 = make([]int, len(v2))
+				copy(cp_ByOwner_v2, v2)
+			}
+			cp.ByOwner[cp_ByOwner_k2] = cp_ByOwner_v2
+		}
+	}
+	return cp
+}`
+	SnapshotEqualFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	"reflect"
+)
+
+// DeepCopy generates a deep copy of Snapshot
+func (o Snapshot) DeepCopy() Snapshot {
+	var cp Snapshot = o
+	if o.Tags != nil {
+		cp.Tags = make([]string, len(o.Tags))
+		copy(cp.Tags, o.Tags)
+	}
+	if o.Scores != nil {
+		cp.Scores = make(map[string][]int, len(o.Scores))
+		for k2, v2 := range o.Scores {
+			var cp_Scores_v2 []int
+			if v2 != nil {
+				cp_Scores_v2 = make([]int, len(v2))
+				copy(cp_Scores_v2, v2)
+			}
+			cp.Scores[k2] = cp_Scores_v2
+		}
+	}
+	if o.Owner != nil {
+		retV := o.Owner.DeepCopy()
+		cp.Owner = &retV
+	}
+	if o.Kids != nil {
+		cp.Kids = make([]Kid, len(o.Kids))
+		copy(cp.Kids, o.Kids)
+		for i2 := range o.Kids {
+			cp.Kids[i2] = o.Kids[i2].DeepCopy()
+		}
+	}
+	if o.Parent != nil {
+		retV := o.Parent.DeepCopy()
+		cp.Parent = &retV
+	}
+	for i2 := range o.Grid {
+		if o.Grid[i2] != nil {
+			cp.Grid[i2] = make([]int, len(o.Grid[i2]))
+			copy(cp.Grid[i2], o.Grid[i2])
+		}
+	}
+	return cp
+}
+
+// DeepEqual reports whether Snapshot is deeply equal to other
+func (o Snapshot) DeepEqual(other Snapshot) bool {
+	if o.Name != other.Name {
+		return false
+	}
+	if len(o.Tags) != len(other.Tags) || (o.Tags == nil) != (other.Tags == nil) {
+		return false
+	}
+	for i2 := range o.Tags {
+		if o.Tags[i2] != other.Tags[i2] {
+			return false
+		}
+	}
+	if len(o.Scores) != len(other.Scores) || (o.Scores == nil) != (other.Scores == nil) {
+		return false
+	}
+	for k2, v2 := range o.Scores {
+		ov2, ok := other.Scores[k2]
+		if !ok {
+			return false
+		}
+		if len(v2) != len(ov2) || (v2 == nil) != (ov2 == nil) {
+			return false
+		}
+		for i3 := range v2 {
+			if v2[i3] != ov2[i3] {
+				return false
+			}
+		}
+	}
+	if (o.Owner == nil) != (other.Owner == nil) {
+		return false
+	}
+	if o.Owner != nil && o.Owner != other.Owner {
+		if o.Owner.Name != other.Owner.Name {
+			return false
+		}
+		if len(o.Owner.Kids) != len(other.Owner.Kids) || (o.Owner.Kids == nil) != (other.Owner.Kids == nil) {
+			return false
+		}
+		for i4 := range o.Owner.Kids {
+			if !reflect.DeepEqual(o.Owner.Kids[i4], other.Owner.Kids[i4]) {
+				return false
+			}
+		}
+	}
+	if len(o.Kids) != len(other.Kids) || (o.Kids == nil) != (other.Kids == nil) {
+		return false
+	}
+	for i2 := range o.Kids {
+		if o.Kids[i2].Name != other.Kids[i2].Name {
+			return false
+		}
+		if len(o.Kids[i2].Kids) != len(other.Kids[i2].Kids) || (o.Kids[i2].Kids == nil) != (other.Kids[i2].Kids == nil) {
+			return false
+		}
+		for i4 := range o.Kids[i2].Kids {
+			if !reflect.DeepEqual(o.Kids[i2].Kids[i4], other.Kids[i2].Kids[i4]) {
+				return false
+			}
+		}
+	}
+	if (o.Parent == nil) != (other.Parent == nil) {
+		return false
+	}
+	if o.Parent != nil && o.Parent != other.Parent {
+		if !(*o.Parent).DeepEqual(*other.Parent) {
+			return false
+		}
+	}
+	if !o.Taken.Equal(other.Taken) {
+		return false
+	}
+	if !reflect.DeepEqual(o.Meta, other.Meta) {
+		return false
+	}
+	for i2 := range o.Grid {
+		if len(o.Grid[i2]) != len(other.Grid[i2]) || (o.Grid[i2] == nil) != (other.Grid[i2] == nil) {
+			return false
+		}
+		for i3 := range o.Grid[i2] {
+			if o.Grid[i2][i3] != other.Grid[i2][i3] {
+				return false
+			}
+		}
+	}
+	return true
+}`
+
+	SnapshotEqualPointerFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	"reflect"
+)
+
+// DeepCopy generates a deep copy of *Snapshot
+func (o *Snapshot) DeepCopy() *Snapshot {
+	var cp Snapshot = *o
+	if o.Tags != nil {
+		cp.Tags = make([]string, len(o.Tags))
+		copy(cp.Tags, o.Tags)
+	}
+	if o.Scores != nil {
+		cp.Scores = make(map[string][]int, len(o.Scores))
+		for k2, v2 := range o.Scores {
+			var cp_Scores_v2 []int
+			if v2 != nil {
+				cp_Scores_v2 = make([]int, len(v2))
+				copy(cp_Scores_v2, v2)
+			}
+			cp.Scores[k2] = cp_Scores_v2
+		}
+	}
+	if o.Owner != nil {
+		retV := o.Owner.DeepCopy()
+		cp.Owner = &retV
+	}
+	if o.Kids != nil {
+		cp.Kids = make([]Kid, len(o.Kids))
+		copy(cp.Kids, o.Kids)
+		for i2 := range o.Kids {
+			cp.Kids[i2] = o.Kids[i2].DeepCopy()
+		}
+	}
+	if o.Parent != nil {
+		cp.Parent = o.Parent.DeepCopy()
+	}
+	for i2 := range o.Grid {
+		if o.Grid[i2] != nil {
+			cp.Grid[i2] = make([]int, len(o.Grid[i2]))
+			copy(cp.Grid[i2], o.Grid[i2])
+		}
+	}
+	return &cp
+}
+
+// DeepEqual reports whether *Snapshot is deeply equal to other
+func (o *Snapshot) DeepEqual(other *Snapshot) bool {
+	if o == nil || other == nil {
+		return o == other
+	}
+	if o.Name != other.Name {
+		return false
+	}
+	if len(o.Tags) != len(other.Tags) || (o.Tags == nil) != (other.Tags == nil) {
+		return false
+	}
+	for i2 := range o.Tags {
+		if o.Tags[i2] != other.Tags[i2] {
+			return false
+		}
+	}
+	if len(o.Scores) != len(other.Scores) || (o.Scores == nil) != (other.Scores == nil) {
+		return false
+	}
+	for k2, v2 := range o.Scores {
+		ov2, ok := other.Scores[k2]
+		if !ok {
+			return false
+		}
+		if len(v2) != len(ov2) || (v2 == nil) != (ov2 == nil) {
+			return false
+		}
+		for i3 := range v2 {
+			if v2[i3] != ov2[i3] {
+				return false
+			}
+		}
+	}
+	if (o.Owner == nil) != (other.Owner == nil) {
+		return false
+	}
+	if o.Owner != nil && o.Owner != other.Owner {
+		if o.Owner.Name != other.Owner.Name {
+			return false
+		}
+		if len(o.Owner.Kids) != len(other.Owner.Kids) || (o.Owner.Kids == nil) != (other.Owner.Kids == nil) {
+			return false
+		}
+		for i4 := range o.Owner.Kids {
+			if !reflect.DeepEqual(o.Owner.Kids[i4], other.Owner.Kids[i4]) {
+				return false
+			}
+		}
+	}
+	if len(o.Kids) != len(other.Kids) || (o.Kids == nil) != (other.Kids == nil) {
+		return false
+	}
+	for i2 := range o.Kids {
+		if o.Kids[i2].Name != other.Kids[i2].Name {
+			return false
+		}
+		if len(o.Kids[i2].Kids) != len(other.Kids[i2].Kids) || (o.Kids[i2].Kids == nil) != (other.Kids[i2].Kids == nil) {
+			return false
+		}
+		for i4 := range o.Kids[i2].Kids {
+			if !reflect.DeepEqual(o.Kids[i2].Kids[i4], other.Kids[i2].Kids[i4]) {
+				return false
+			}
+		}
+	}
+	if !o.Parent.DeepEqual(other.Parent) {
+		return false
+	}
+	if !o.Taken.Equal(other.Taken) {
+		return false
+	}
+	if !reflect.DeepEqual(o.Meta, other.Meta) {
+		return false
+	}
+	for i2 := range o.Grid {
+		if len(o.Grid[i2]) != len(other.Grid[i2]) || (o.Grid[i2] == nil) != (other.Grid[i2] == nil) {
+			return false
+		}
+		for i3 := range o.Grid[i2] {
+			if o.Grid[i2][i3] != other.Grid[i2][i3] {
+				return false
+			}
+		}
+	}
+	return true
+}`
+	AuditedFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	"github.com/globusdigital/deep-copy/testdata/aliases"
+)
+
+// DeepCopy generates a deep copy of Audited
+func (o Audited) DeepCopy() Audited {
+	var cp Audited = o
+	if o.Updated != nil {
+		cp.Updated = make([]Stamp, len(o.Updated))
+		copy(cp.Updated, o.Updated)
+	}
+	if o.Labels != nil {
+		cp.Labels = make(LabelSet, len(o.Labels))
+		for k2, v2 := range o.Labels {
+			var cp_Labels_v2 []string
+			if v2 != nil {
+				cp_Labels_v2 = make([]string, len(v2))
+				copy(cp_Labels_v2, v2)
+			}
+			cp.Labels[k2] = cp_Labels_v2
+		}
+	}
+	if o.History != nil {
+		cp.History = make([]LabelSet, len(o.History))
+		copy(cp.History, o.History)
+		for i2 := range o.History {
+			if o.History[i2] != nil {
+				cp.History[i2] = make(LabelSet, len(o.History[i2]))
+				for k3, v3 := range o.History[i2] {
+					var cp_History_i2_v3 []string
+					if v3 != nil {
+						cp_History_i2_v3 = make([]string, len(v3))
+						copy(cp_History_i2_v3, v3)
+					}
+					cp.History[i2][k3] = cp_History_i2_v3
+				}
+			}
+		}
+	}
+	if o.Previous != nil {
+		cp.Previous = new(Stamp)
+		*cp.Previous = *o.Previous
+	}
+	if o.Names != nil {
+		cp.Names = make([]NameList, len(o.Names))
+		copy(cp.Names, o.Names)
+		for i2 := range o.Names {
+			cp.Names[i2] = o.Names[i2].DeepCopy()
+		}
+	}
+	if o.Aliases != nil {
+		retV := o.Aliases.DeepCopy()
+		cp.Aliases = &retV
+	}
+	if o.Extra != nil {
+		cp.Extra = new(aliases.Labels)
+		*cp.Extra = *o.Extra
+		if *o.Extra != nil {
+			*cp.Extra = make(aliases.Labels, len(*o.Extra))
+			for k3, v3 := range *o.Extra {
+				var cp_Extra_v3 []string
+				if v3 != nil {
+					cp_Extra_v3 = make([]string, len(v3))
+					copy(cp_Extra_v3, v3)
+				}
+				(*cp.Extra)[k3] = cp_Extra_v3
+			}
+		}
+	}
+	return cp
+}`
+	TaggedItemsFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of TaggedItems
+func (o TaggedItems) DeepCopy() TaggedItems {
+	var cp TaggedItems = o
+	if o.Items != nil {
+		cp.Items = make([]struct {
+			Name string
+			Tags []string "json:\"tags\""
+		}, len(o.Items))
+		copy(cp.Items, o.Items)
+		for i2 := range o.Items {
+			if o.Items[i2].Tags != nil {
+				cp.Items[i2].Tags = make([]string, len(o.Items[i2].Tags))
+				copy(cp.Items[i2].Tags, o.Items[i2].Tags)
+			}
+		}
+	}
+	if o.Refs != nil {
+		cp.Refs = make([]*struct{ Tags []string }, len(o.Refs))
+		copy(cp.Refs, o.Refs)
+		for i2 := range o.Refs {
+			if o.Refs[i2] != nil {
+				cp.Refs[i2] = new(struct{ Tags []string })
+				*cp.Refs[i2] = *o.Refs[i2]
+				if o.Refs[i2].Tags != nil {
+					cp.Refs[i2].Tags = make([]string, len(o.Refs[i2].Tags))
+					copy(cp.Refs[i2].Tags, o.Refs[i2].Tags)
+				}
+			}
+		}
+	}
+	return cp
+}`
+	EventLogDeepFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of EventLog
+func (o EventLog) DeepCopy() EventLog {
+	var cp EventLog = o
+	switch impl2 := o.Last.(type) {
+	case ClickEvent:
+		var cp_Last_impl2 ClickEvent = impl2
+		if impl2.Tags != nil {
+			cp_Last_impl2.Tags = make([]string, len(impl2.Tags))
+			copy(cp_Last_impl2.Tags, impl2.Tags)
+		}
+		cp.Last = cp_Last_impl2
+	case *KeyEvent:
+		var cp_Last_impl2 *KeyEvent
+		if impl2 != nil {
+			cp_Last_impl2 = new(KeyEvent)
+			*cp_Last_impl2 = *impl2
+			if impl2.Keys != nil {
+				cp_Last_impl2.Keys = make(map[string]int, len(impl2.Keys))
+				for k5, v5 := range impl2.Keys {
+					cp_Last_impl2.Keys[k5] = v5
+				}
+			}
+		}
+		cp.Last = cp_Last_impl2
+	}
+	return cp
+}`
+	TaggedDeepFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	"github.com/globusdigital/deep-copy/testdata/generic"
+)
+
+// DeepCopy generates a deep copy of Tagged
+func (o Tagged) DeepCopy() Tagged {
+	var cp Tagged = o
+	cp.ID = 0
+	cp.Name = ""
+	cp.Active = false
+	cp.Stamp = Baz{}
+	cp.Box = generic.Box[int]{}
+	cp.Cache = nil
+	cp.Index = nil
+	if o.Shared != nil {
+		cp.Shared = make([]int, len(o.Shared))
+		copy(cp.Shared, o.Shared)
+	}
+	if o.Copied != nil {
+		cp.Copied = make([]int, len(o.Copied))
+		copy(cp.Copied, o.Copied)
+	}
+	if o.Ignored != nil {
+		cp.Ignored = make(map[string]string, len(o.Ignored))
+		for k2, v2 := range o.Ignored {
+			cp.Ignored[k2] = v2
+		}
+	}
+	return cp
+}`
+	SplitHolderFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of SplitHolder
+func (o SplitHolder) DeepCopy() SplitHolder {
+	var cp SplitHolder = o
+	if o.Parts != nil {
+		cp.Parts = make([]Split, len(o.Parts))
+		copy(cp.Parts, o.Parts)
+		for i2 := range o.Parts {
+			cp.Parts[i2] = o.Parts[i2].DeepCopy()
+		}
+	}
+	if o.Part != nil {
+		retV := o.Part.DeepCopy()
+		cp.Part = &retV
+	}
+	return cp
+}`
+	BuffersCapFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Buffers
+func (o Buffers) DeepCopy() Buffers {
+	var cp Buffers = o
+	if o.Raw != nil {
+		cp.Raw = make([]byte, len(o.Raw), cap(o.Raw))
+		copy(cp.Raw, o.Raw)
+	}
+	if o.Ints != nil {
+		cp.Ints = make([]int, len(o.Ints), cap(o.Ints))
+		copy(cp.Ints, o.Ints)
+	}
+	if o.Named != nil {
+		cp.Named = make([]byte, len(o.Named), cap(o.Named))
+		copy(cp.Named, o.Named)
+	}
+	if o.Nested != nil {
+		cp.Nested = make([][]byte, len(o.Nested), cap(o.Nested))
+		copy(cp.Nested, o.Nested)
+		for i2 := range o.Nested {
+			if o.Nested[i2] != nil {
+				cp.Nested[i2] = make([]byte, len(o.Nested[i2]), cap(o.Nested[i2]))
+				copy(cp.Nested[i2], o.Nested[i2])
+			}
+		}
+	}
+	return cp
+}`
+
+	BuffersCapAllocatorFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Buffers
+func (o Buffers) DeepCopy() Buffers {
+	var cp Buffers = o
+	if o.Raw != nil {
+		cp.Raw = make([]byte, len(o.Raw), cap(o.Raw))
+		copy(cp.Raw, o.Raw)
+	}
+	if o.Ints != nil {
+		cp.Ints = make([]int, len(o.Ints), cap(o.Ints))
+		copy(cp.Ints, o.Ints)
+	}
+	if o.Named != nil {
+		cp.Named = make([]byte, len(o.Named), cap(o.Named))
+		copy(cp.Named, o.Named)
+	}
+	if o.Nested != nil {
+		cp.Nested = make([][]byte, len(o.Nested), cap(o.Nested))
+		copy(cp.Nested, o.Nested)
+		for i2 := range o.Nested {
+			if o.Nested[i2] != nil {
+				cp.Nested[i2] = make([]byte, len(o.Nested[i2]), cap(o.Nested[i2]))
+				copy(cp.Nested[i2], o.Nested[i2])
+			}
+		}
+	}
+	return cp
+}
+
+// DeepCopyWith generates a deep copy of Buffers, allocated by the allocator
+func (o Buffers) DeepCopyWith(allocator BuffersAllocator) Buffers {
+	var cp Buffers = o
+	if o.Raw != nil {
+		cp.Raw = allocator.MakeByteSlice(cap(o.Raw))[:len(o.Raw)]
+		copy(cp.Raw, o.Raw)
+	}
+	if o.Ints != nil {
+		cp.Ints = allocator.MakeIntSlice(cap(o.Ints))[:len(o.Ints)]
+		copy(cp.Ints, o.Ints)
+	}
+	if o.Named != nil {
+		cp.Named = allocator.MakeByteSlice(cap(o.Named))[:len(o.Named)]
+		copy(cp.Named, o.Named)
+	}
+	if o.Nested != nil {
+		cp.Nested = allocator.MakeByteSliceSlice(cap(o.Nested))[:len(o.Nested)]
+		copy(cp.Nested, o.Nested)
+		for i2 := range o.Nested {
+			if o.Nested[i2] != nil {
+				cp.Nested[i2] = allocator.MakeByteSlice(cap(o.Nested[i2]))[:len(o.Nested[i2])]
+				copy(cp.Nested[i2], o.Nested[i2])
+			}
+		}
+	}
+	return cp
+}
+
+// BuffersAllocator allocates the values copied by Buffers.DeepCopyWith
+type BuffersAllocator interface {
+	MakeByteSlice(len int) []byte
+	MakeByteSliceSlice(len int) [][]byte
+	MakeIntSlice(len int) []int
+}`
+	CratesFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Crate[T]
+func (o Crate[T]) DeepCopy() Crate[T] {
+	var cp Crate[T] = o
+	if o.Items != nil {
+		cp.Items = make([]T, len(o.Items))
+		copy(cp.Items, o.Items)
+	}
+	if o.Label != nil {
+		cp.Label = new(string)
+		*cp.Label = *o.Label
+	}
+	return cp
+}
+
+// DeepCopy generates a deep copy of Crates
+func (o Crates) DeepCopy() Crates {
+	var cp Crates = o
+	cp.Ints = o.Ints.DeepCopy()
+	cp.Strings = o.Strings.DeepCopy()
+	if o.Kids != nil {
+		cp.Kids = make([]Crate[*Kid], len(o.Kids))
+		copy(cp.Kids, o.Kids)
+		for i2 := range o.Kids {
+			cp.Kids[i2] = o.Kids[i2].DeepCopy()
+		}
+	}
+	return cp
+}`
+
+	CratesInlineFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Crates
+func (o Crates) DeepCopy() Crates {
+	var cp Crates = o
+	if o.Ints.Items != nil {
+		cp.Ints.Items = make([]int, len(o.Ints.Items))
+		copy(cp.Ints.Items, o.Ints.Items)
+	}
+	if o.Ints.Label != nil {
+		cp.Ints.Label = new(string)
+		*cp.Ints.Label = *o.Ints.Label
+	}
+	if o.Strings.Items != nil {
+		cp.Strings.Items = make([]string, len(o.Strings.Items))
+		copy(cp.Strings.Items, o.Strings.Items)
+	}
+	if o.Strings.Label != nil {
+		cp.Strings.Label = new(string)
+		*cp.Strings.Label = *o.Strings.Label
+	}
+	if o.Kids != nil {
+		cp.Kids = make([]Crate[*Kid], len(o.Kids))
+		copy(cp.Kids, o.Kids)
+		for i2 := range o.Kids {
+			if o.Kids[i2].Items != nil {
+				cp.Kids[i2].Items = make([]*Kid, len(o.Kids[i2].Items))
+				copy(cp.Kids[i2].Items, o.Kids[i2].Items)
+				for i4 := range o.Kids[i2].Items {
+					if o.Kids[i2].Items[i4] != nil {
+						retV := o.Kids[i2].Items[i4].DeepCopy()
+						cp.Kids[i2].Items[i4] = &retV
+					}
+				}
+			}
+			if o.Kids[i2].Label != nil {
+				cp.Kids[i2].Label = new(string)
+				*cp.Kids[i2].Label = *o.Kids[i2].Label
+			}
+		}
+	}
+	return cp
+}`
+	BatchSkipFieldFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Batch
+func (o Batch) DeepCopy() Batch {
+	var cp Batch = o
+	if o.Groups != nil {
+		cp.Groups = make([][]*Kid, len(o.Groups))
+		copy(cp.Groups, o.Groups)
+		for i2 := range o.Groups {
+			if o.Groups[i2] != nil {
+				cp.Groups[i2] = make([]*Kid, len(o.Groups[i2]))
+				copy(cp.Groups[i2], o.Groups[i2])
+				for i3 := range o.Groups[i2] {
+					if o.Groups[i2][i3] != nil {
+						retV := o.Groups[i2][i3].DeepCopy()
+						cp.Groups[i2][i3] = &retV
+					}
+				}
+			}
+		}
+	}
+	return cp
+}`
+
+	BatchSkipElementsFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Batch
+func (o Batch) DeepCopy() Batch {
+	var cp Batch = o
+	if o.Items != nil {
+		cp.Items = make([]*Kid, len(o.Items))
+		copy(cp.Items, o.Items)
+	}
+	if o.Groups != nil {
+		cp.Groups = make([][]*Kid, len(o.Groups))
+		copy(cp.Groups, o.Groups)
+		for i2 := range o.Groups {
+			if o.Groups[i2] != nil {
+				cp.Groups[i2] = make([]*Kid, len(o.Groups[i2]))
+				copy(cp.Groups[i2], o.Groups[i2])
+			}
+		}
+	}
+	return cp
+}`
+	SetsFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Sets
+func (o Sets) DeepCopy() Sets {
+	var cp Sets = o
+	if o.Names != nil {
+		cp.Names = make(map[string]struct{}, len(o.Names))
+		for k2 := range o.Names {
+			cp.Names[k2] = struct{}{}
+		}
+	}
+	if o.Markers != nil {
+		cp.Markers = make([]struct{}, len(o.Markers))
+		copy(cp.Markers, o.Markers)
+	}
+	if o.Nested != nil {
+		cp.Nested = make(map[string]map[int]struct{}, len(o.Nested))
+		for k2, v2 := range o.Nested {
+			var cp_Nested_v2 map[int]struct{}
+			if v2 != nil {
+				cp_Nested_v2 = make(map[int]struct{}, len(v2))
+				for k3 := range v2 {
+					cp_Nested_v2[k3] = struct{}{}
+				}
+			}
+			cp.Nested[k2] = cp_Nested_v2
+		}
+	}
+	return cp
+}`
+
+	CacheFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Cache[V]
+func (o Cache[V]) DeepCopy() Cache[V] {
+	var cp Cache[V] = o
+	if o.Entries != nil {
+		cp.Entries = make(map[string]V, len(o.Entries))
+		for k2, v2 := range o.Entries {
+			cp.Entries[k2] = v2
+		}
+	}
+	return cp
+}`
+
+	CopierCacheFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of CopierCache[V]
+func (o CopierCache[V]) DeepCopy() CopierCache[V] {
+	var cp CopierCache[V] = o
+	if o.Entries != nil {
+		cp.Entries = make(map[string]V, len(o.Entries))
+		for k2, v2 := range o.Entries {
+			var cp_Entries_v2 V
+			cp_Entries_v2 = v2.DeepCopy()
+			cp.Entries[k2] = cp_Entries_v2
+		}
+	}
+	return cp
+}`
+
+	ObjectListFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of ObjectList
+func (o ObjectList) DeepCopy() ObjectList {
+	var cp ObjectList = o
+	if o.Items != nil {
+		cp.Items = make([]Object, len(o.Items))
+		copy(cp.Items, o.Items)
+		for i2 := range o.Items {
+			if o.Items[i2] != nil {
+				cp.Items[i2] = o.Items[i2].DeepCopyObject()
+			}
+		}
+	}
+	if o.Owner != nil {
+		cp.Owner = o.Owner.DeepCopyObject()
+	}
+	return cp
+}`
+
+	ObjectListSharedFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of ObjectList
+func (o ObjectList) DeepCopy() ObjectList {
+	var cp ObjectList = o
+	if o.Items != nil {
+		cp.Items = make([]Object, len(o.Items))
+		copy(cp.Items, o.Items)
+	}
+	return cp
+}`
+
+	CallbacksFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Callbacks
+func (o Callbacks) DeepCopy() Callbacks {
+	var cp Callbacks = o
+	if o.OnEvent != nil {
+		cp.OnEvent = make(map[string]func(), len(o.OnEvent))
+		for k2, v2 := range o.OnEvent {
+			cp.OnEvent[k2] = v2
+		}
+	}
+	return cp
+}`
+
+	EventRingImpls = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of EventRing
+func (o EventRing) DeepCopy() EventRing {
+	var cp EventRing = o
+	for i2 := range o.Recent {
+		switch impl3 := o.Recent[i2].(type) {
+		case ClickEvent:
+			var cp_Recent_i2_impl3 ClickEvent = impl3
+			if impl3.Tags != nil {
+				cp_Recent_i2_impl3.Tags = make([]string, len(impl3.Tags))
+				copy(cp_Recent_i2_impl3.Tags, impl3.Tags)
+			}
+			cp.Recent[i2] = cp_Recent_i2_impl3
+		case *KeyEvent:
+			var cp_Recent_i2_impl3 *KeyEvent
+			if impl3 != nil {
+				cp_Recent_i2_impl3 = new(KeyEvent)
+				*cp_Recent_i2_impl3 = *impl3
+				if impl3.Keys != nil {
+					cp_Recent_i2_impl3.Keys = make(map[string]int, len(impl3.Keys))
+					for k6, v6 := range impl3.Keys {
+						cp_Recent_i2_impl3.Keys[k6] = v6
+					}
+				}
+			}
+			cp.Recent[i2] = cp_Recent_i2_impl3
+		}
+	}
+	return cp
+}`
+
+	GridsFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Grids
+func (o Grids) DeepCopy() Grids {
+	var cp Grids = o
+	for i2 := range o.Bars {
+		if o.Bars[i2] != nil {
+			cp.Bars[i2] = new(Bar)
+			*cp.Bars[i2] = *o.Bars[i2]
+			if o.Bars[i2].Slice != nil {
+				cp.Bars[i2].Slice = make([]string, len(o.Bars[i2].Slice))
+				copy(cp.Bars[i2].Slice, o.Bars[i2].Slice)
+			}
+		}
+	}
+	if o.History != nil {
+		cp.History = make([]Matrix, len(o.History))
+		copy(cp.History, o.History)
+	}
+	if o.Nested != nil {
+		cp.Nested = make(map[string]Ptrs, len(o.Nested))
+		for k2, v2 := range o.Nested {
+			var cp_Nested_v2 Ptrs = v2
+			for i3 := range v2 {
+				if v2[i3] != nil {
+					cp_Nested_v2[i3] = new(Bar)
+					*cp_Nested_v2[i3] = *v2[i3]
+					if v2[i3].Slice != nil {
+						cp_Nested_v2[i3].Slice = make([]string, len(v2[i3].Slice))
+						copy(cp_Nested_v2[i3].Slice, v2[i3].Slice)
+					}
+				}
+			}
+			cp.Nested[k2] = cp_Nested_v2
+		}
+	}
+	return cp
+}`
+
+	GridsPtrsFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Grids
+func (o Grids) DeepCopy() Grids {
+	var cp Grids = o
+	cp.Bars = o.Bars.DeepCopy()
+	if o.History != nil {
+		cp.History = make([]Matrix, len(o.History))
+		copy(cp.History, o.History)
+	}
+	if o.Nested != nil {
+		cp.Nested = make(map[string]Ptrs, len(o.Nested))
+		for k2, v2 := range o.Nested {
+			var cp_Nested_v2 Ptrs
+			cp_Nested_v2 = v2.DeepCopy()
+			cp.Nested[k2] = cp_Nested_v2
+		}
+	}
+	return cp
+}
+
+// DeepCopy generates a deep copy of Ptrs
+func (o Ptrs) DeepCopy() Ptrs {
+	var cp Ptrs = o
+	for i := range o {
+		if o[i] != nil {
+			cp[i] = new(Bar)
+			*cp[i] = *o[i]
+			if o[i].Slice != nil {
+				cp[i].Slice = make([]string, len(o[i].Slice))
+				copy(cp[i].Slice, o[i].Slice)
+			}
+		}
+	}
+	return cp
+}`
+
+	FailureFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Failure
+func (o Failure) DeepCopy() Failure {
+	var cp Failure = o
+	if o.Causes != nil {
+		cp.Causes = make([]AppError, len(o.Causes))
+		copy(cp.Causes, o.Causes)
+	}
+	if o.Copied != nil {
+		cp.Copied = o.Copied.DeepCopy()
+	}
+	return cp
+}`
+
+	FailureImplsFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Failure
+func (o Failure) DeepCopy() Failure {
+	var cp Failure = o
+	switch impl2 := o.Err.(type) {
+	case *CodeError:
+		var cp_Err_impl2 *CodeError
+		if impl2 != nil {
+			cp_Err_impl2 = new(CodeError)
+			*cp_Err_impl2 = *impl2
+			if impl2.Codes != nil {
+				cp_Err_impl2.Codes = make([]int, len(impl2.Codes))
+				copy(cp_Err_impl2.Codes, impl2.Codes)
+			}
+		}
+		cp.Err = cp_Err_impl2
+	}
+	if o.Causes != nil {
+		cp.Causes = make([]AppError, len(o.Causes))
+		copy(cp.Causes, o.Causes)
+		for i2 := range o.Causes {
+			switch impl3 := o.Causes[i2].(type) {
+			case *CodeError:
+				var cp_Causes_i2_impl3 *CodeError
+				if impl3 != nil {
+					cp_Causes_i2_impl3 = new(CodeError)
+					*cp_Causes_i2_impl3 = *impl3
+					if impl3.Codes != nil {
+						cp_Causes_i2_impl3.Codes = make([]int, len(impl3.Codes))
+						copy(cp_Causes_i2_impl3.Codes, impl3.Codes)
+					}
+				}
+				cp.Causes[i2] = cp_Causes_i2_impl3
+			}
+		}
+	}
+	if o.Copied != nil {
+		cp.Copied = o.Copied.DeepCopy()
+	}
+	return cp
+}`
+
+	BarIndexFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of BarIndex
+func (o BarIndex) DeepCopy() BarIndex {
+	var cp BarIndex = o
+	if o.Groups != nil {
+		cp.Groups = new(map[string][]*Bar)
+		*cp.Groups = *o.Groups
+		if *o.Groups != nil {
+			*cp.Groups = make(map[string][]*Bar, len(*o.Groups))
+			for k3, v3 := range *o.Groups {
+				var cp_Groups_v3 []*Bar
+				if v3 != nil {
+					cp_Groups_v3 = make([]*Bar, len(v3))
+					copy(cp_Groups_v3, v3)
+					for i4 := range v3 {
+						if v3[i4] != nil {
+							cp_Groups_v3[i4] = new(Bar)
+							*cp_Groups_v3[i4] = *v3[i4]
+							if v3[i4].Slice != nil {
+								cp_Groups_v3[i4].Slice = make([]string, len(v3[i4].Slice))
+								copy(cp_Groups_v3[i4].Slice, v3[i4].Slice)
+							}
+						}
+					}
+				}
+				(*cp.Groups)[k3] = cp_Groups_v3
+			}
+		}
+	}
+	return cp
+}`
+
+	RoutesFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Routes
+func (o Routes) DeepCopy() Routes {
+	var cp Routes = o
+	if o.ByPath != nil {
+		cp.ByPath = make(map[string]NamedHandler, len(o.ByPath))
+		for k2, v2 := range o.ByPath {
+			cp.ByPath[k2] = v2
+		}
+	}
+	if o.Fallback != nil {
+		cp.Fallback = o.Fallback.DeepCopy()
+	}
+	if o.Shapes != nil {
+		cp.Shapes = make([]NamedShape, len(o.Shapes))
+		copy(cp.Shapes, o.Shapes)
+		for i2 := range o.Shapes {
+			if o.Shapes[i2] != nil {
+				cp.Shapes[i2] = o.Shapes[i2].DeepCopy()
+			}
+		}
+	}
+	return cp
+}`
+
+	RoutesImplsFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	"github.com/globusdigital/deep-copy/testdata/handlers"
+)
+
+// DeepCopy generates a deep copy of Routes
+func (o Routes) DeepCopy() Routes {
+	var cp Routes = o
+	switch impl2 := o.Default.(type) {
+	case handlers.Mux:
+		var cp_Default_impl2 handlers.Mux = impl2
+		if impl2.Routes != nil {
+			cp_Default_impl2.Routes = make(map[string]string, len(impl2.Routes))
+			for k4, v4 := range impl2.Routes {
+				cp_Default_impl2.Routes[k4] = v4
+			}
+		}
+		cp.Default = cp_Default_impl2
+	}
+	if o.ByPath != nil {
+		cp.ByPath = make(map[string]NamedHandler, len(o.ByPath))
+		for k2, v2 := range o.ByPath {
+			var cp_ByPath_v2 NamedHandler = v2
+			switch impl3 := v2.(type) {
+			case handlers.Mux:
+				var cp_ByPath_v2_impl3 handlers.Mux = impl3
+				if impl3.Routes != nil {
+					cp_ByPath_v2_impl3.Routes = make(map[string]string, len(impl3.Routes))
+					for k5, v5 := range impl3.Routes {
+						cp_ByPath_v2_impl3.Routes[k5] = v5
+					}
+				}
+				cp_ByPath_v2 = cp_ByPath_v2_impl3
+			}
+			cp.ByPath[k2] = cp_ByPath_v2
+		}
+	}
+	if o.Fallback != nil {
+		cp.Fallback = o.Fallback.DeepCopy()
+	}
+	if o.Shapes != nil {
+		cp.Shapes = make([]NamedShape, len(o.Shapes))
+		copy(cp.Shapes, o.Shapes)
+		for i2 := range o.Shapes {
+			if o.Shapes[i2] != nil {
+				cp.Shapes[i2] = o.Shapes[i2].DeepCopy()
+			}
+		}
+	}
+	return cp
+}`
+
+	FramesReuseFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopyInto generates a deep copy of *Frame into out
+//
+// The slices of out are reused, with the values their elements point to:
+// but for the slices of o themselves, which are never reused, out must not
+// share them with anything else.
+func (o *Frame) DeepCopyInto(out *Frame) {
+	prev_Data := out.Data
+	prev_Bars := out.Bars
+	prev_Header_Tags := out.Header.Tags
+	*out = *o
+	if o.Data != nil {
+		if len(o.Data) > 0 && cap(prev_Data) > 0 && &prev_Data[:1][0] == &o.Data[0] {
+			prev_Data = nil
+		}
+		if prev_Data != nil && cap(prev_Data) >= len(o.Data) {
+			out.Data = prev_Data[:len(o.Data)]
+		} else {
+			out.Data = make([]byte, len(o.Data))
+		}
+		copy(out.Data, o.Data)
+	}
+	if o.Bars != nil {
+		if len(o.Bars) > 0 && cap(prev_Bars) > 0 && &prev_Bars[:1][0] == &o.Bars[0] {
+			prev_Bars = nil
+		}
+		if prev_Bars != nil && cap(prev_Bars) >= len(o.Bars) {
+			out.Bars = prev_Bars[:len(o.Bars)]
+		} else {
+			out.Bars = make([]*Bar, len(o.Bars))
+			copy(out.Bars, prev_Bars)
+		}
+		for i2 := range o.Bars {
+			if o.Bars[i2] == nil {
+				out.Bars[i2] = nil
+				continue
+			}
+			if out.Bars[i2] == nil {
+				out.Bars[i2] = new(Bar)
+			}
+			*out.Bars[i2] = *o.Bars[i2]
+			if o.Bars[i2].Slice != nil {
+				out.Bars[i2].Slice = make([]string, len(o.Bars[i2].Slice))
+				copy(out.Bars[i2].Slice, o.Bars[i2].Slice)
+			}
+		}
+	}
+	if o.Header.Tags != nil {
+		if len(o.Header.Tags) > 0 && cap(prev_Header_Tags) > 0 && &prev_Header_Tags[:1][0] == &o.Header.Tags[0] {
+			prev_Header_Tags = nil
+		}
+		if prev_Header_Tags != nil && cap(prev_Header_Tags) >= len(o.Header.Tags) {
+			out.Header.Tags = prev_Header_Tags[:len(o.Header.Tags)]
+		} else {
+			out.Header.Tags = make([]string, len(o.Header.Tags))
+		}
+		copy(out.Header.Tags, o.Header.Tags)
+	}
+	if o.Prev != nil {
+		out.Prev = new(FrameHeader)
+		*out.Prev = *o.Prev
+		if o.Prev.Tags != nil {
+			out.Prev.Tags = make([]string, len(o.Prev.Tags))
+			copy(out.Prev.Tags, o.Prev.Tags)
+		}
+	}
+	if o.Indexes != nil {
+		out.Indexes = make(map[string][]int, len(o.Indexes))
+		for k2, v2 := range o.Indexes {
+			var out_Indexes_v2 []int
+			if v2 != nil {
+				out_Indexes_v2 = make([]int, len(v2))
+				copy(out_Indexes_v2, v2)
+			}
+			out.Indexes[k2] = out_Indexes_v2
+		}
+	}
+}
+
+// DeepCopy generates a deep copy of *Frame
+func (o *Frame) DeepCopy() *Frame {
+	var cp Frame
+	o.DeepCopyInto(&cp)
+	return &cp
+}
+
+// DeepCopyInto generates a deep copy of *Samples into out
+//
+// The slices of out are reused, with the values their elements point to:
+// but for the slices of o themselves, which are never reused, out must not
+// share them with anything else.
+func (o *Samples) DeepCopyInto(out *Samples) {
+	prev := *out
+	*out = *o
+	if *o != nil {
+		if len(*o) > 0 && cap(prev) > 0 && &prev[:1][0] == &(*o)[0] {
+			prev = nil
+		}
+		if prev != nil && cap(prev) >= len(*o) {
+			*out = prev[:len(*o)]
+		} else {
+			*out = make([]float64, len(*o))
+		}
+		copy(*out, *o)
+	}
+}
+
+// DeepCopy generates a deep copy of *Samples
+func (o *Samples) DeepCopy() *Samples {
+	var cp Samples
+	o.DeepCopyInto(&cp)
+	return &cp
+}
+
+// DeepCopyInto generates a deep copy of *Track into out
+//
+// The slices of out are reused, with the values their elements point to:
+// but for the slices of o themselves, which are never reused, out must not
+// share them with anything else.
+func (o *Track) DeepCopyInto(out *Track) {
+	prev_Frames := out.Frames
+	*out = *o
+	if o.Frames != nil {
+		if len(o.Frames) > 0 && cap(prev_Frames) > 0 && &prev_Frames[:1][0] == &o.Frames[0] {
+			prev_Frames = nil
+		}
+		if prev_Frames != nil && cap(prev_Frames) >= len(o.Frames) {
+			out.Frames = prev_Frames[:len(o.Frames)]
+		} else {
+			out.Frames = make([]*Frame, len(o.Frames))
+			copy(out.Frames, prev_Frames)
+		}
+		for i2 := range o.Frames {
+			if o.Frames[i2] == nil {
+				out.Frames[i2] = nil
+				continue
+			}
+			if out.Frames[i2] == nil {
+				out.Frames[i2] = new(Frame)
+			}
+			o.Frames[i2].DeepCopyInto(out.Frames[i2])
+		}
+	}
+}
+
+// DeepCopy generates a deep copy of *Track
+func (o *Track) DeepCopy() *Track {
+	var cp Track
+	o.DeepCopyInto(&cp)
+	return &cp
+}`
+
+	PacksFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Packs
+func (o Packs) DeepCopy() Packs {
+	var cp Packs = o
+	if o.Ints != nil {
+		cp.Ints = make([]Pack[int], len(o.Ints))
+		copy(cp.Ints, o.Ints)
+		for i2 := range o.Ints {
+			cp.Ints[i2] = o.Ints[i2].DeepCopy()
+		}
+	}
+	if o.Strings != nil {
+		cp.Strings = make(map[string]Pack[string], len(o.Strings))
+		for k2, v2 := range o.Strings {
+			var cp_Strings_v2 Pack[string]
+			cp_Strings_v2 = v2.DeepCopy()
+			cp.Strings[k2] = cp_Strings_v2
+		}
+	}
+	if o.Ptrs != nil {
+		cp.Ptrs = make([]*Pack[int], len(o.Ptrs))
+		copy(cp.Ptrs, o.Ptrs)
+		for i2 := range o.Ptrs {
+			if o.Ptrs[i2] != nil {
+				retV := o.Ptrs[i2].DeepCopy()
+				cp.Ptrs[i2] = &retV
+			}
+		}
+	}
+	return cp
+}`
+
+	RefChansFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of RefChans
+func (o RefChans) DeepCopy() RefChans {
+	var cp RefChans = o
+	if o.Batches != nil {
+		cp.Batches = make(chan []int, cap(o.Batches))
+	}
+	if o.Counts != nil {
+		cp.Counts = make(chan int, cap(o.Counts))
+	}
+	if o.Bars != nil {
+		cp.Bars = make(map[string]chan *Bar, len(o.Bars))
+		for k2, v2 := range o.Bars {
+			var cp_Bars_v2 chan *Bar
+			if v2 != nil {
+				cp_Bars_v2 = make(chan *Bar, cap(v2))
+			}
+			cp.Bars[k2] = cp_Bars_v2
+		}
+	}
+	return cp
+}`
+
+	RefChansDropFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of RefChans
+func (o RefChans) DeepCopy() RefChans {
+	var cp RefChans = o
+	cp.Batches = nil
+	cp.Counts = nil
+	if o.Bars != nil {
+		cp.Bars = make(map[string]chan *Bar, len(o.Bars))
+		for k2 := range o.Bars {
+			cp.Bars[k2] = nil
+		}
+	}
+	cp.Sends = nil
+	return cp
+}`
+
+	PairIndexFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of PairIndex
+func (o PairIndex) DeepCopy() PairIndex {
+	var cp PairIndex = o
+	if o.Pairs != nil {
+		cp.Pairs = make(map[[2]*Bar][]string, len(o.Pairs))
+		for k2, v2 := range o.Pairs {
+			var cp_Pairs_v2 []string
+			if v2 != nil {
+				cp_Pairs_v2 = make([]string, len(v2))
+				copy(cp_Pairs_v2, v2)
+			}
+			cp.Pairs[k2] = cp_Pairs_v2
+		}
+	}
+	return cp
+}`
+
+	PairIndexDeepKeysFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of PairIndex
+func (o PairIndex) DeepCopy() PairIndex {
+	var cp PairIndex = o
+	if o.Pairs != nil {
+		cp.Pairs = make(map[[2]*Bar][]string, len(o.Pairs))
+		for k2, v2 := range o.Pairs {
+			var cp_Pairs_k2 [2]*Bar = k2
+			for i3 := range k2 {
+				if k2[i3] != nil {
+					cp_Pairs_k2[i3] = new(Bar)
+					*cp_Pairs_k2[i3] = *k2[i3]
+					if k2[i3].Slice != nil {
+						cp_Pairs_k2[i3].Slice = make([]string, len(k2[i3].Slice))
+						copy(cp_Pairs_k2[i3].Slice, k2[i3].Slice)
+					}
+				}
+			}
+			var cp_Pairs_v2 []string
+			if v2 != nil {
+				cp_Pairs_v2 = make([]string, len(v2))
+				copy(cp_Pairs_v2, v2)
+			}
+			cp.Pairs[cp_Pairs_k2] = cp_Pairs_v2
+		}
+	}
+	return cp
+}`
+
+	BookSliceFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Book
+func (o Book) DeepCopy() Book {
+	var cp Book = o
+	if o.Tags != nil {
+		cp.Tags = make([]string, len(o.Tags))
+		copy(cp.Tags, o.Tags)
+	}
+	if o.Sequel != nil {
+		retV := o.Sequel.DeepCopy()
+		cp.Sequel = &retV
+	}
+	return cp
+}
+
+// DeepCopyBookSlice generates a deep copy of the []Book slice
+func DeepCopyBookSlice(s []Book) []Book {
+	var cp []Book
+	if s != nil {
+		cp = make([]Book, len(s))
+		copy(cp, s)
+		for i2 := range s {
+			cp[i2] = s[i2].DeepCopy()
+		}
+	}
+	return cp
+}`
+
+	BookSliceFuncsFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopyBook generates a deep copy of *Book
+func DeepCopyBook(o *Book) *Book {
+	var cp Book = *o
+	if o.Tags != nil {
+		cp.Tags = make([]string, len(o.Tags))
+		copy(cp.Tags, o.Tags)
+	}
+	if o.Sequel != nil {
+		cp.Sequel = DeepCopyBook(o.Sequel)
+	}
+	return &cp
+}
+
+// DeepCopyBookSlice generates a deep copy of the []Book slice
+func DeepCopyBookSlice(s []Book) []Book {
+	var cp []Book
+	if s != nil {
+		cp = make([]Book, len(s))
+		copy(cp, s)
+		for i2 := range s {
+			if retV := DeepCopyBook(&s[i2]); retV != nil {
+				cp[i2] = *retV
+			}
+		}
+	}
+	return cp
+}`
+
+	GenericTreeSliceFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of GenericTree[T]
+func (o GenericTree[T]) DeepCopy() GenericTree[T] {
+	var cp GenericTree[T] = o
+	if o.Children != nil {
+		cp.Children = make([]*GenericTree[T], len(o.Children))
+		copy(cp.Children, o.Children)
+		for i2 := range o.Children {
+			if o.Children[i2] != nil {
+				retV := o.Children[i2].DeepCopy()
+				cp.Children[i2] = &retV
+			}
+		}
+	}
+	return cp
+}
+
+// DeepCopyGenericTreeSlice generates a deep copy of the []GenericTree[T] slice
+func DeepCopyGenericTreeSlice[T any](s []GenericTree[T]) []GenericTree[T] {
+	var cp []GenericTree[T]
+	if s != nil {
+		cp = make([]GenericTree[T], len(s))
+		copy(cp, s)
+		for i2 := range s {
+			cp[i2] = s[i2].DeepCopy()
+		}
+	}
+	return cp
+}`
+
+	ChanPointersFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of ChanPointers
+func (o ChanPointers) DeepCopy() ChanPointers {
+	var cp ChanPointers = o
+	if o.Signal != nil {
+		cp.Signal = new(chan int)
+		*cp.Signal = *o.Signal
+		if *o.Signal != nil {
+			*cp.Signal = make(chan int, cap(*o.Signal))
+		}
+	}
+	if o.Recv != nil {
+		cp.Recv = new(<-chan int)
+		*cp.Recv = *o.Recv
+	}
+	return cp
+}`
+
+	ChanPointersDropFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of ChanPointers
+func (o ChanPointers) DeepCopy() ChanPointers {
+	var cp ChanPointers = o
+	if o.Signal != nil {
+		cp.Signal = new(chan int)
+		*cp.Signal = *o.Signal
+		*cp.Signal = nil
+	}
+	if o.Recv != nil {
+		cp.Recv = new(<-chan int)
+		*cp.Recv = *o.Recv
+		*cp.Recv = nil
+	}
+	return cp
+}`
+
+	SessionOptsFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// SessionCopyOptions selects the optional members deeply copied by DeepCopyOpts,
+// which are otherwise shared with the copy.
+type SessionCopyOptions struct {
+	CopyCache        bool
+	CopyHistoryPages bool
+}
+
+// DeepCopyOpts generates a deep copy of Session, with the optional members selected by opts
+func (o Session) DeepCopyOpts(opts SessionCopyOptions) Session {
+	var cp Session = o
+	if o.Roles != nil {
+		cp.Roles = make([]string, len(o.Roles))
+		copy(cp.Roles, o.Roles)
+	}
+	if opts.CopyCache {
+		if o.Cache != nil {
+			cp.Cache = make(map[string][]byte, len(o.Cache))
+			for k2, v2 := range o.Cache {
+				var cp_Cache_v2 []byte
+				if v2 != nil {
+					cp_Cache_v2 = make([]byte, len(v2))
+					copy(cp_Cache_v2, v2)
+				}
+				cp.Cache[k2] = cp_Cache_v2
+			}
+		}
+	}
+	if opts.CopyHistoryPages {
+		if o.History.Pages != nil {
+			cp.History.Pages = make([]string, len(o.History.Pages))
+			copy(cp.History.Pages, o.History.Pages)
+		}
 	}
+	return cp
 }
 
-var re = regexp.MustCompile(`generated by .*deep-copy.*; DO NOT EDIT.`)
+// DeepCopy generates a deep copy of Session, with all of its optional members
+func (o Session) DeepCopy() Session {
+	return o.DeepCopyOpts(SessionCopyOptions{CopyCache: true, CopyHistoryPages: true})
+}`
 
-func normalizeComment(in []byte) []byte {
-	return re.ReplaceAll(bytes.TrimSpace(in), []byte("generated by deep-copy; DO NOT EDIT."))
+	SessionOptsFuncsFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// SessionCopyOptions selects the optional members deeply copied by DeepCopyOptsSession,
+// which are otherwise shared with the copy.
+type SessionCopyOptions struct {
+	CopyCache bool
 }
 
-const (
-	FooFile = `// generated by deep-copy; DO NOT EDIT.
+// DeepCopyOptsSession generates a deep copy of *Session, with the optional members selected by opts
+func DeepCopyOptsSession(o *Session, opts SessionCopyOptions) *Session {
+	var cp Session = *o
+	if o.Roles != nil {
+		cp.Roles = make([]string, len(o.Roles))
+		copy(cp.Roles, o.Roles)
+	}
+	if opts.CopyCache {
+		if o.Cache != nil {
+			cp.Cache = make(map[string][]byte, len(o.Cache))
+			for k2, v2 := range o.Cache {
+				var cp_Cache_v2 []byte
+				if v2 != nil {
+					cp_Cache_v2 = make([]byte, len(v2))
+					copy(cp_Cache_v2, v2)
+				}
+				cp.Cache[k2] = cp_Cache_v2
+			}
+		}
+	}
+	if o.History.Pages != nil {
+		cp.History.Pages = make([]string, len(o.History.Pages))
+		copy(cp.History.Pages, o.History.Pages)
+	}
+	return &cp
+}
+
+// DeepCopySession generates a deep copy of *Session, with all of its optional members
+func DeepCopySession(o *Session) *Session {
+	return DeepCopyOptsSession(o, SessionCopyOptions{CopyCache: true})
+}`
+
+	DispatcherFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Dispatcher
+func (o Dispatcher) DeepCopy() Dispatcher {
+	var cp Dispatcher = o
+	if o.Handlers != nil {
+		cp.Handlers = make([]Handler, len(o.Handlers))
+		copy(cp.Handlers, o.Handlers)
+	}
+	if o.Named != nil {
+		cp.Named = make(map[string]Handler, len(o.Named))
+		for k2, v2 := range o.Named {
+			cp.Named[k2] = v2
+		}
+	}
+	if o.Next != nil {
+		cp.Next = new(Handler)
+		*cp.Next = *o.Next
+	}
+	return cp
+}`
+
+	DispatcherOtherFile = `// generated by deep-copy; DO NOT EDIT.
+
+package other
+
+import (
+	"github.com/globusdigital/deep-copy/testdata"
+)
+
+// DeepCopyDispatcher generates a deep copy of testdata.Dispatcher
+func DeepCopyDispatcher(o testdata.Dispatcher) testdata.Dispatcher {
+	var cp testdata.Dispatcher = o
+	if o.Handlers != nil {
+		cp.Handlers = make([]testdata.Handler, len(o.Handlers))
+		copy(cp.Handlers, o.Handlers)
+	}
+	if o.Named != nil {
+		cp.Named = make(map[string]testdata.Handler, len(o.Named))
+		for k2, v2 := range o.Named {
+			cp.Named[k2] = v2
+		}
+	}
+	if o.Next != nil {
+		cp.Next = new(testdata.Handler)
+		*cp.Next = *o.Next
+	}
+	return cp
+}`
+
+	FooBarExplainFile = `// generated by deep-copy; DO NOT EDIT.
 
 package testdata
 
 // DeepCopy generates a deep copy of Foo
 func (o Foo) DeepCopy() Foo {
 	var cp Foo = o
-	if o.Map != nil {
+	if o.Map != nil { // deep copy: map of string to *Bar
 		cp.Map = make(map[string]*Bar, len(o.Map))
 		for k2, v2 := range o.Map {
 			var cp_Map_v2 *Bar
-			if v2 != nil {
-				cp_Map_v2 = new(Bar)
-				*cp_Map_v2 = *v2
-				if v2.Slice != nil {
-					cp_Map_v2.Slice = make([]string, len(v2.Slice))
-					copy(cp_Map_v2.Slice, v2.Slice)
-				}
+			if v2 != nil { // deep copy: pointer to Bar
+				retV := v2.DeepCopy() // reused DeepCopy
+				cp_Map_v2 = &retV
 			}
 			cp.Map[k2] = cp_Map_v2
 		}
 	}
-	if o.ch != nil {
+	if o.ch != nil { // new channel of the same capacity, without the buffered values
 		cp.ch = make(chan float32, cap(o.ch))
 	}
-	if o.baz.StringPointer != nil {
+	if o.baz.StringPointer != nil { // deep copy: pointer to string
 		cp.baz.StringPointer = new(string)
 		*cp.baz.StringPointer = *o.baz.StringPointer
 	}
 	return cp
+}
+
+// DeepCopy generates a deep copy of Bar
+func (o Bar) DeepCopy() Bar {
+	var cp Bar = o
+	if o.Slice != nil { // deep copy: slice of string
+		cp.Slice = make([]string, len(o.Slice))
+		copy(cp.Slice, o.Slice)
+	}
+	return cp
 }`
-	FooPointerFile = `// generated by deep-copy; DO NOT EDIT.
+
+	EventRefExplainFile = `// generated by deep-copy; DO NOT EDIT.
 
 package testdata
 
-// DeepCopy generates a deep copy of *Foo
-func (o *Foo) DeepCopy() *Foo {
-	var cp Foo = *o
-	if o.Map != nil {
-		cp.Map = make(map[string]*Bar, len(o.Map))
-		for k2, v2 := range o.Map {
-			var cp_Map_v2 *Bar
+// DeepCopy generates a deep copy of EventRef
+func (o EventRef) DeepCopy() EventRef {
+	var cp EventRef = o
+	if o.Event != nil { // deep copy: pointer to Event
+		cp.Event = new(Event)
+		*cp.Event = *o.Event
+		switch impl3 := (*o.Event).(type) { // type switch over the implementations of Event
+		case ClickEvent:
+			var cp_Event_impl3 ClickEvent = impl3
+			if impl3.Tags != nil { // deep copy: slice of string
+				cp_Event_impl3.Tags = make([]string, len(impl3.Tags))
+				copy(cp_Event_impl3.Tags, impl3.Tags)
+			}
+			*cp.Event = cp_Event_impl3
+		case *KeyEvent:
+			var cp_Event_impl3 *KeyEvent
+			if impl3 != nil { // deep copy: pointer to KeyEvent
+				cp_Event_impl3 = new(KeyEvent)
+				*cp_Event_impl3 = *impl3
+				if impl3.Keys != nil { // deep copy: map of string to int
+					cp_Event_impl3.Keys = make(map[string]int, len(impl3.Keys))
+					for k6, v6 := range impl3.Keys {
+						cp_Event_impl3.Keys[k6] = v6
+					}
+				}
+			}
+			*cp.Event = cp_Event_impl3
+		}
+	}
+	return cp
+}`
+
+	ConfigsFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Configs
+func (o Configs) DeepCopy() Configs {
+	var cp Configs = o
+	if o.Layers != nil {
+		cp.Layers = make([]Config, len(o.Layers))
+		copy(cp.Layers, o.Layers)
+		for i2 := range o.Layers {
+			if o.Layers[i2] != nil {
+				cp.Layers[i2] = make(Config, len(o.Layers[i2]))
+				for k3, v3 := range o.Layers[i2] {
+					cp.Layers[i2][k3] = v3
+				}
+			}
+		}
+	}
+	if o.ByEnv != nil {
+		cp.ByEnv = make(map[string][]Config, len(o.ByEnv))
+		for k2, v2 := range o.ByEnv {
+			var cp_ByEnv_v2 []Config
 			if v2 != nil {
-				cp_Map_v2 = new(Bar)
-				*cp_Map_v2 = *v2
-				if v2.Slice != nil {
-					cp_Map_v2.Slice = make([]string, len(v2.Slice))
-					copy(cp_Map_v2.Slice, v2.Slice)
+				cp_ByEnv_v2 = make([]Config, len(v2))
+				copy(cp_ByEnv_v2, v2)
+				for i3 := range v2 {
+					if v2[i3] != nil {
+						cp_ByEnv_v2[i3] = make(Config, len(v2[i3]))
+						for k4, v4 := range v2[i3] {
+							cp_ByEnv_v2[i3][k4] = v4
+						}
+					}
 				}
 			}
-			cp.Map[k2] = cp_Map_v2
+			cp.ByEnv[k2] = cp_ByEnv_v2
 		}
 	}
-	if o.ch != nil {
-		cp.ch = make(chan float32, cap(o.ch))
+	return cp
+}`
+
+	AssetsFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+import (
+	"embed"
+)
+
+// DeepCopy generates a deep copy of Assets
+func (o Assets) DeepCopy() Assets {
+	var cp Assets = o
+	if o.Bundles != nil {
+		cp.Bundles = make(map[string]embed.FS, len(o.Bundles))
+		for k2, v2 := range o.Bundles {
+			cp.Bundles[k2] = v2
+		}
 	}
-	if o.baz.StringPointer != nil {
-		cp.baz.StringPointer = new(string)
-		*cp.baz.StringPointer = *o.baz.StringPointer
+	if o.Patterns != nil {
+		cp.Patterns = make([]string, len(o.Patterns))
+		copy(cp.Patterns, o.Patterns)
 	}
-	return &cp
+	return cp
 }`
-	FooPointerSkipSliceFile = `// generated by deep-copy; DO NOT EDIT.
+
+	StringerIndexFile = `// generated by deep-copy; DO NOT EDIT.
 
 package testdata
 
-// DeepCopy generates a deep copy of *Foo
-func (o *Foo) DeepCopy() *Foo {
-	var cp Foo = *o
-	if o.Map != nil {
-		cp.Map = make(map[string]*Bar, len(o.Map))
-		for k2, v2 := range o.Map {
-			var cp_Map_v2 *Bar
+import (
+	"fmt"
+)
+
+// DeepCopy generates a deep copy of StringerIndex
+func (o StringerIndex) DeepCopy() StringerIndex {
+	var cp StringerIndex = o
+	if o.Bars != nil {
+		cp.Bars = make(map[fmt.Stringer]*Bar, len(o.Bars))
+		for k2, v2 := range o.Bars {
+			var cp_Bars_v2 *Bar
 			if v2 != nil {
-				cp_Map_v2 = new(Bar)
-				*cp_Map_v2 = *v2
+				cp_Bars_v2 = new(Bar)
+				*cp_Bars_v2 = *v2
+				if v2.Slice != nil {
+					cp_Bars_v2.Slice = make([]string, len(v2.Slice))
+					copy(cp_Bars_v2.Slice, v2.Slice)
+				}
 			}
-			cp.Map[k2] = cp_Map_v2
+			cp.Bars[k2] = cp_Bars_v2
 		}
 	}
-	if o.ch != nil {
-		cp.ch = make(chan float32, cap(o.ch))
+	return cp
+}`
+
+	FixedArraysFile = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of FixedArrays
+func (o FixedArrays) DeepCopy() FixedArrays {
+	var cp FixedArrays = o
+	cp.Root = o.Root.DeepCopy()
+	for i2 := range o.Buffers {
+		if o.Buffers[i2] != nil {
+			cp.Buffers[i2] = make([]byte, len(o.Buffers[i2]))
+			copy(cp.Buffers[i2], o.Buffers[i2])
+		}
 	}
-	if o.baz.StringPointer != nil {
-		cp.baz.StringPointer = new(string)
-		*cp.baz.StringPointer = *o.baz.StringPointer
+	return cp
+}
+
+// DeepCopy generates a deep copy of ArrayNode
+func (o ArrayNode) DeepCopy() ArrayNode {
+	var cp ArrayNode = o
+	for i2 := range o.Children {
+		if o.Children[i2] != nil {
+			retV := o.Children[i2].DeepCopy()
+			cp.Children[i2] = &retV
+		}
 	}
-	return &cp
+	return cp
 }`
-	FooSkipMapFile = `// generated by deep-copy; DO NOT EDIT.
+
+	SketchDynamicFile = `// generated by deep-copy; DO NOT EDIT.
 
 package testdata
 
-// DeepCopy generates a deep copy of Foo
-func (o Foo) DeepCopy() Foo {
-	var cp Foo = o
-	if o.Map != nil {
-		cp.Map = make(map[string]*Bar, len(o.Map))
-		for k2, v2 := range o.Map {
-			cp.Map[k2] = v2
+import (
+	"reflect"
+)
+
+// DeepCopy generates a deep copy of Sketch
+func (o Sketch) DeepCopy() Sketch {
+	var cp Sketch = o
+	if o.Main != nil {
+		if rv := reflect.ValueOf(o.Main); rv.Kind() != reflect.Pointer || !rv.IsNil() {
+			if rm := rv.MethodByName("DeepCopy"); rm.IsValid() && rm.Type().NumIn() == 0 && rm.Type().NumOut() == 1 {
+				if rc, ok := rm.Call(nil)[0].Interface().(Figure); ok {
+					cp.Main = rc
+				}
+			}
 		}
 	}
-	if o.ch != nil {
-		cp.ch = make(chan float32, cap(o.ch))
-	}
-	if o.baz.StringPointer != nil {
-		cp.baz.StringPointer = new(string)
-		*cp.baz.StringPointer = *o.baz.StringPointer
+	if o.Figures != nil {
+		cp.Figures = make([]Figure, len(o.Figures))
+		copy(cp.Figures, o.Figures)
+		for i2 := range o.Figures {
+			if o.Figures[i2] != nil {
+				if rv := reflect.ValueOf(o.Figures[i2]); rv.Kind() != reflect.Pointer || !rv.IsNil() {
+					if rm := rv.MethodByName("DeepCopy"); rm.IsValid() && rm.Type().NumIn() == 0 && rm.Type().NumOut() == 1 {
+						if rc, ok := rm.Call(nil)[0].Interface().(Figure); ok {
+							cp.Figures[i2] = rc
+						}
+					}
+				}
+			}
+		}
 	}
 	return cp
 }`
-	AlphaPointer = `// generated by deep-copy; DO NOT EDIT.
+
+	HolderFile = `// generated by deep-copy; DO NOT EDIT.
 
 package testdata
 
-// DeepCopy generates a deep copy of Alpha
-func (o Alpha) DeepCopy() Alpha {
-	var cp Alpha = o
-	if o.B != nil {
-		cp.B = o.B.DeepCopy()
-	}
-	cp.G = o.G.DeepCopy()
-	if o.D != nil {
-		retV := o.D.DeepCopy()
-		cp.D = &retV
+// DeepCopy generates a deep copy of Holder[T]
+func (o Holder[T]) DeepCopy() Holder[T] {
+	var cp Holder[T] = o
+	if o.Items != nil {
+		cp.Items = make([]T, len(o.Items))
+		copy(cp.Items, o.Items)
 	}
-	{
-		retV := o.E.DeepCopy()
-		cp.E = *retV
+	if o.Ptr != nil {
+		cp.Ptr = new(T)
+		*cp.Ptr = *o.Ptr
 	}
 	return cp
 }`
-	SlicePointer = `// generated by deep-copy; DO NOT EDIT.
+
+	HolderFuncsFile = `// generated by deep-copy; DO NOT EDIT.
 
 package testdata
 
-// DeepCopy generates a deep copy of SlicePointer
-func (o SlicePointer) DeepCopy() SlicePointer {
-	var cp SlicePointer = o
-	if o != nil {
-		cp = make([]*int, len(o))
-		copy(cp, o)
+// DeepCopyHolder generates a deep copy of Holder[T]
+func DeepCopyHolder[T any](o Holder[T]) Holder[T] {
+	var cp Holder[T] = o
+	if o.Items != nil {
+		cp.Items = make([]T, len(o.Items))
+		copy(cp.Items, o.Items)
+	}
+	if o.Ptr != nil {
+		cp.Ptr = new(T)
+		*cp.Ptr = *o.Ptr
 	}
 	return cp
 }`
-	FooAlphaSkips = `// generated by deep-copy; DO NOT EDIT.
+
+	ResourceIntoFile = `// generated by deep-copy; DO NOT EDIT.
 
 package testdata
 
-// DeepCopy generates a deep copy of Foo
-func (o Foo) DeepCopy() Foo {
-	var cp Foo = o
-	if o.Map != nil {
-		cp.Map = make(map[string]*Bar, len(o.Map))
-		for k2, v2 := range o.Map {
-			cp.Map[k2] = v2
-		}
+// DeepCopyInto generates a deep copy of *Resource into out
+func (o *Resource) DeepCopyInto(out *Resource) {
+	*out = *o
+	o.Meta.DeepCopyInto(&out.Meta)
+	if o.Owner != nil {
+		out.Owner = new(ObjectMeta)
+		o.Owner.DeepCopyInto(out.Owner)
 	}
-	if o.baz.StringPointer != nil {
-		cp.baz.StringPointer = new(string)
-		*cp.baz.StringPointer = *o.baz.StringPointer
+	if o.Extras != nil {
+		out.Extras = make([]ObjectMeta, len(o.Extras))
+		copy(out.Extras, o.Extras)
+		for i2 := range o.Extras {
+			o.Extras[i2].DeepCopyInto(&out.Extras[i2])
+		}
 	}
-	return cp
 }
 
-// DeepCopy generates a deep copy of Alpha
-func (o Alpha) DeepCopy() Alpha {
-	var cp Alpha = o
-	if o.B != nil {
-		cp.B = o.B.DeepCopy()
-	}
-	cp.G = o.G.DeepCopy()
-	return cp
+// DeepCopy generates a deep copy of *Resource
+func (o *Resource) DeepCopy() *Resource {
+	var cp Resource
+	o.DeepCopyInto(&cp)
+	return &cp
 }`
 
-	Issue3SliceSimpleStruct = `// generated by deep-copy; DO NOT EDIT.
+	RecursiveImpliedFile = `// generated by deep-copy; DO NOT EDIT.
 
 package testdata
 
-// DeepCopy generates a deep copy of *I3WithSlice
-func (o *I3WithSlice) DeepCopy() *I3WithSlice {
-	var cp I3WithSlice = *o
-	if o.a != nil {
-		cp.a = make([]I3SimpleStruct, len(o.a))
-		copy(cp.a, o.a)
+// DeepCopy generates a deep copy of BinaryTree
+func (o BinaryTree) DeepCopy() BinaryTree {
+	var cp BinaryTree = o
+	if o.Root != nil {
+		retV := o.Root.DeepCopy()
+		cp.Root = &retV
 	}
-	return &cp
+	return cp
+}
+
+// DeepCopy generates a deep copy of Org
+func (o Org) DeepCopy() Org {
+	var cp Org = o
+	cp.Root = o.Root.DeepCopy()
+	return cp
+}
+
+// DeepCopy generates a deep copy of TreeNode
+func (o TreeNode) DeepCopy() TreeNode {
+	var cp TreeNode = o
+	if o.Left != nil {
+		retV := o.Left.DeepCopy()
+		cp.Left = &retV
+	}
+	if o.Right != nil {
+		retV := o.Right.DeepCopy()
+		cp.Right = &retV
+	}
+	return cp
+}
+
+// DeepCopy generates a deep copy of Dept
+func (o Dept) DeepCopy() Dept {
+	var cp Dept = o
+	if o.Head != nil {
+		cp.Head = new(Employee)
+		*cp.Head = *o.Head
+		if o.Head.Dept != nil {
+			retV := o.Head.Dept.DeepCopy()
+			cp.Head.Dept = &retV
+		}
+		if o.Head.Skills != nil {
+			cp.Head.Skills = make([]string, len(o.Head.Skills))
+			copy(cp.Head.Skills, o.Head.Skills)
+		}
+	}
+	if o.Subs != nil {
+		cp.Subs = make([]*Dept, len(o.Subs))
+		copy(cp.Subs, o.Subs)
+		for i2 := range o.Subs {
+			if o.Subs[i2] != nil {
+				retV := o.Subs[i2].DeepCopy()
+				cp.Subs[i2] = &retV
+			}
+		}
+	}
+	return cp
 }`
-	Issue3MapSimpleStructKey = `// generated by deep-copy; DO NOT EDIT.
+	DocumentCloneFile = `// generated by deep-copy; DO NOT EDIT.
 
 package testdata
 
-// DeepCopy generates a deep copy of *I3WithMap
-func (o *I3WithMap) DeepCopy() *I3WithMap {
-	var cp I3WithMap = *o
-	if o.a != nil {
-		cp.a = make(map[I3SimpleStruct]string, len(o.a))
-		for k2, v2 := range o.a {
-			cp.a[k2] = v2
+// Clone generates a deep copy of Document
+func (o Document) Clone() Document {
+	var cp Document = o
+	cp.Body = o.Body.Clone()
+	if o.Version != nil {
+		cp.Version = o.Version.Clone()
+	}
+	if o.Notes != nil {
+		cp.Notes = make([]Snippet, len(o.Notes))
+		copy(cp.Notes, o.Notes)
+		for i2 := range o.Notes {
+			cp.Notes[i2] = o.Notes[i2].Clone()
 		}
 	}
-	return &cp
+	return cp
+}
+
+// Clone generates a deep copy of Snippet
+func (o Snippet) Clone() Snippet {
+	var cp Snippet = o
+	if o.Lines != nil {
+		cp.Lines = make([]string, len(o.Lines))
+		copy(cp.Lines, o.Lines)
+	}
+	return cp
 }`
-	Issue3MapSimpleStructVal = `// generated by deep-copy; DO NOT EDIT.
+	LedgerSkipTypesFile = `// generated by deep-copy; DO NOT EDIT.
 
 package testdata
 
-// DeepCopy generates a deep copy of I3WithMapVal
-func (o I3WithMapVal) DeepCopy() I3WithMapVal {
-	var cp I3WithMapVal = o
-	if o.a != nil {
-		cp.a = make(map[string]I3SimpleStruct, len(o.a))
-		for k2, v2 := range o.a {
-			cp.a[k2] = v2
+// DeepCopy generates a deep copy of *Ledger
+func (o *Ledger) DeepCopy() *Ledger {
+	var cp Ledger
+	cp.Owner = o.Owner
+	cp.Symbols = o.Symbols
+	cp.Entries = o.Entries
+	cp.Created = o.Created
+	if o.Symbols != nil {
+		cp.Symbols = make([]Symbol, len(o.Symbols))
+		copy(cp.Symbols, o.Symbols)
+	}
+	if o.Entries != nil {
+		cp.Entries = make(map[string][]int, len(o.Entries))
+		for k2, v2 := range o.Entries {
+			var cp_Entries_v2 []int
+			if v2 != nil {
+				cp_Entries_v2 = make([]int, len(v2))
+				copy(cp_Entries_v2, v2)
+			}
+			cp.Entries[k2] = cp_Entries_v2
 		}
 	}
-	return cp
+	return &cp
 }`
-
-	Issue7ShadowedMapVars = `// generated by deep-copy; DO NOT EDIT.
+	StackSkipFile = `// generated by deep-copy; DO NOT EDIT.
 
 package testdata
 
-// DeepCopy generates a deep copy of SomeStruct2
-func (o SomeStruct2) DeepCopy() SomeStruct2 {
-	var cp SomeStruct2 = o
-	if o.mapStruct != nil {
-		cp.mapStruct = make(map[string]SomeStruct, len(o.mapStruct))
-		for k2, v2 := range o.mapStruct {
-			var cp_mapStruct_v2 SomeStruct
-			if v2.mapSlice != nil {
-				cp_mapStruct_v2.mapSlice = make(map[string][]string, len(v2.mapSlice))
-				for k4, v4 := range v2.mapSlice {
-					var cp_mapStruct_v2_mapSlice_v4 []string
-					if v4 != nil {
-						cp_mapStruct_v2_mapSlice_v4 = make([]string, len(v4))
-						copy(cp_mapStruct_v2_mapSlice_v4, v4)
+// DeepCopy generates a deep copy of Stack
+func (o Stack) DeepCopy() Stack {
+	var cp Stack = o
+	if o.Top.Store.Hits != nil {
+		cp.Top.Store.Hits = make([]int, len(o.Top.Store.Hits))
+		copy(cp.Top.Store.Hits, o.Top.Store.Hits)
+	}
+	if o.Bottom.Store.Entries != nil {
+		cp.Bottom.Store.Entries = make(map[string][]byte, len(o.Bottom.Store.Entries))
+		for k4, v4 := range o.Bottom.Store.Entries {
+			var cp_Bottom_Store_Entries_v4 []byte
+			if v4 != nil {
+				cp_Bottom_Store_Entries_v4 = make([]byte, len(v4))
+				copy(cp_Bottom_Store_Entries_v4, v4)
+			}
+			cp.Bottom.Store.Entries[k4] = cp_Bottom_Store_Entries_v4
+		}
+	}
+	if o.Bottom.Store.Hits != nil {
+		cp.Bottom.Store.Hits = make([]int, len(o.Bottom.Store.Hits))
+		copy(cp.Bottom.Store.Hits, o.Bottom.Store.Hits)
+	}
+	if o.Index != nil {
+		cp.Index = make(map[string]Layer, len(o.Index))
+		for k2, v2 := range o.Index {
+			var cp_Index_v2 Layer = v2
+			if v2.Store.Entries != nil {
+				cp_Index_v2.Store.Entries = make(map[string][]byte, len(v2.Store.Entries))
+				for k5, v5 := range v2.Store.Entries {
+					var cp_Index_v2_Store_Entries_v5 []byte
+					if v5 != nil {
+						cp_Index_v2_Store_Entries_v5 = make([]byte, len(v5))
+						copy(cp_Index_v2_Store_Entries_v5, v5)
 					}
-					cp_mapStruct_v2.mapSlice[k4] = cp_mapStruct_v2_mapSlice_v4
+					cp_Index_v2.Store.Entries[k5] = cp_Index_v2_Store_Entries_v5
 				}
 			}
-			cp.mapStruct[k2] = cp_mapStruct_v2
+			cp.Index[k2] = cp_Index_v2
 		}
 	}
 	return cp
 }`
-
-	Issue7ShadowedMapVars2 = `// generated by deep-copy; DO NOT EDIT.
+	RouteAliasingFile = `// generated by deep-copy; DO NOT EDIT.
 
 package testdata
 
-// DeepCopy generates a deep copy of SomeStruct
-func (o SomeStruct) DeepCopy() SomeStruct {
-	var cp SomeStruct = o
-	if o.mapSlice != nil {
-		cp.mapSlice = make(map[string][]string, len(o.mapSlice))
-		for k2, v2 := range o.mapSlice {
-			var cp_mapSlice_v2 []string
-			if v2 != nil {
-				cp_mapSlice_v2 = make([]string, len(v2))
-				copy(cp_mapSlice_v2, v2)
+// DeepCopy generates a deep copy of Route
+func (o Route) DeepCopy() Route {
+	var cp Route = o
+	visited := make(map[any]any)
+	if o.Start != nil {
+		if p, ok := visited[o.Start]; ok {
+			cp.Start = p.(*Waypoint)
+		} else {
+			cp.Start = new(Waypoint)
+			*cp.Start = *o.Start
+			if o.Start.Tags != nil {
+				cp.Start.Tags = make([]string, len(o.Start.Tags))
+				copy(cp.Start.Tags, o.Start.Tags)
 			}
-			cp.mapSlice[k2] = cp_mapSlice_v2
+			visited[o.Start] = cp.Start
 		}
 	}
-	return cp
-}
-
-// DeepCopy generates a deep copy of SomeStruct2
-func (o SomeStruct2) DeepCopy() SomeStruct2 {
-	var cp SomeStruct2 = o
-	if o.mapStruct != nil {
-		cp.mapStruct = make(map[string]SomeStruct, len(o.mapStruct))
-		for k2, v2 := range o.mapStruct {
-			var cp_mapStruct_v2 SomeStruct
-			cp_mapStruct_v2 = v2.DeepCopy()
-			cp.mapStruct[k2] = cp_mapStruct_v2
+	if o.End != nil {
+		if p, ok := visited[o.End]; ok {
+			cp.End = p.(*Waypoint)
+		} else {
+			cp.End = new(Waypoint)
+			*cp.End = *o.End
+			if o.End.Tags != nil {
+				cp.End.Tags = make([]string, len(o.End.Tags))
+				copy(cp.End.Tags, o.End.Tags)
+			}
+			visited[o.End] = cp.End
+		}
+	}
+	if o.Stops != nil {
+		cp.Stops = make([]*Waypoint, len(o.Stops))
+		copy(cp.Stops, o.Stops)
+		for i2 := range o.Stops {
+			if o.Stops[i2] != nil {
+				if p, ok := visited[o.Stops[i2]]; ok {
+					cp.Stops[i2] = p.(*Waypoint)
+				} else {
+					cp.Stops[i2] = new(Waypoint)
+					*cp.Stops[i2] = *o.Stops[i2]
+					if o.Stops[i2].Tags != nil {
+						cp.Stops[i2].Tags = make([]string, len(o.Stops[i2].Tags))
+						copy(cp.Stops[i2].Tags, o.Stops[i2].Tags)
+					}
+					visited[o.Stops[i2]] = cp.Stops[i2]
+				}
+			}
+		}
+	}
+	if o.Named != nil {
+		cp.Named = make(map[string]*Waypoint, len(o.Named))
+		for k2, v2 := range o.Named {
+			var cp_Named_v2 *Waypoint
+			if v2 != nil {
+				if p, ok := visited[v2]; ok {
+					cp_Named_v2 = p.(*Waypoint)
+				} else {
+					cp_Named_v2 = new(Waypoint)
+					*cp_Named_v2 = *v2
+					if v2.Tags != nil {
+						cp_Named_v2.Tags = make([]string, len(v2.Tags))
+						copy(cp_Named_v2.Tags, v2.Tags)
+					}
+					visited[v2] = cp_Named_v2
+				}
+			}
+			cp.Named[k2] = cp_Named_v2
 		}
 	}
 	return cp
 }`
+	AllFile = `// generated by deep-copy; DO NOT EDIT.
 
-	Issue10StructCH = `// generated by deep-copy; DO NOT EDIT.
+package all
 
-package testdata
+// DeepCopy generates a deep copy of Catalog
+func (o Catalog) DeepCopy() Catalog {
+	var cp Catalog = o
+	cp.Items = o.Items.DeepCopy()
+	cp.Index = o.Index.DeepCopy()
+	if o.Owners != nil {
+		cp.Owners = make([]string, len(o.Owners))
+		copy(cp.Owners, o.Owners)
+	}
+	return cp
+}
 
-// DeepCopy generates a deep copy of StructCH
-func (o StructCH) DeepCopy() StructCH {
-	var cp StructCH = o
-	if o.Nested != nil {
-		cp.Nested = make([]StructNested, len(o.Nested))
-		copy(cp.Nested, o.Nested)
-		for i2 := range o.Nested {
-			if o.Nested[i2].B != nil {
-				cp.Nested[i2].B = new(int)
-				*cp.Nested[i2].B = *o.Nested[i2].B
+// DeepCopy generates a deep copy of Index
+func (o Index) DeepCopy() Index {
+	var cp Index = o
+	if o != nil {
+		cp = make(Index, len(o))
+		for k, v := range o {
+			var cp_v *Item
+			if v != nil {
+				retV := v.DeepCopy()
+				cp_v = &retV
 			}
+			cp[k] = cp_v
 		}
 	}
 	return cp
-}`
+}
 
-	PointerThatImplementsDeepcopy = `// generated by deep-copy; DO NOT EDIT.
+// DeepCopy generates a deep copy of Item
+func (o Item) DeepCopy() Item {
+	var cp Item = o
+	return cp
+}
 
-package somepkg
+// DeepCopy generates a deep copy of Items
+func (o Items) DeepCopy() Items {
+	var cp Items = o
+	if o != nil {
+		cp = make([]Item, len(o))
+		copy(cp, o)
+		for i := range o {
+			cp[i] = o[i].DeepCopy()
+		}
+	}
+	return cp
+}
 
-// DeepCopy generates a deep copy of SomeStruct
-func (o SomeStruct) DeepCopy() SomeStruct {
-	var cp SomeStruct = o
-	if o.AnotherStruct != nil {
-		cp.AnotherStruct = o.AnotherStruct.DeepCopy()
+// DeepCopy generates a deep copy of Shelf
+func (o Shelf) DeepCopy() Shelf {
+	var cp Shelf = o
+	cp.Manual = o.Manual.DeepCopy()
+	if o.Catalog != nil {
+		retV := o.Catalog.DeepCopy()
+		cp.Catalog = &retV
 	}
 	return cp
 }`
-
-	Issue12NestedSlices = `// generated by deep-copy; DO NOT EDIT.
+	LookupFile = `// generated by deep-copy; DO NOT EDIT.
 
 package testdata
 
-// DeepCopy generates a deep copy of I12NestedSlices
-func (o I12NestedSlices) DeepCopy() I12NestedSlices {
-	var cp I12NestedSlices = o
-	if o.Slices != nil {
-		cp.Slices = make([][][]int, len(o.Slices))
-		copy(cp.Slices, o.Slices)
-		for i2 := range o.Slices {
-			if o.Slices[i2] != nil {
-				cp.Slices[i2] = make([][]int, len(o.Slices[i2]))
-				copy(cp.Slices[i2], o.Slices[i2])
-				for i3 := range o.Slices[i2] {
-					if o.Slices[i2][i3] != nil {
-						cp.Slices[i2][i3] = make([]int, len(o.Slices[i2][i3]))
-						copy(cp.Slices[i2][i3], o.Slices[i2][i3])
-					}
+import (
+	"github.com/globusdigital/deep-copy/testdata/remote/keypkg"
+	github_com_globusdigital_deep_copy_testdata_remote_legacy_valpkg "github.com/globusdigital/deep-copy/testdata/remote/legacy/valpkg"
+	"github.com/globusdigital/deep-copy/testdata/remote/valpkg"
+)
+
+// DeepCopy generates a deep copy of Lookup
+func (o Lookup) DeepCopy() Lookup {
+	var cp Lookup = o
+	if o.ByKey != nil {
+		cp.ByKey = make(map[keypkg.Key]*valpkg.Val, len(o.ByKey))
+		for k2, v2 := range o.ByKey {
+			var cp_ByKey_v2 *valpkg.Val
+			if v2 != nil {
+				cp_ByKey_v2 = new(valpkg.Val)
+				*cp_ByKey_v2 = *v2
+				if v2.Data != nil {
+					cp_ByKey_v2.Data = make([]byte, len(v2.Data))
+					copy(cp_ByKey_v2.Data, v2.Data)
 				}
 			}
+			cp.ByKey[k2] = cp_ByKey_v2
 		}
 	}
-	return cp
-}`
-
-	Issue12MapWithSliceValues = `// generated by deep-copy; DO NOT EDIT.
-
-package testdata
-
-// DeepCopy generates a deep copy of I12StructWithMapOfSlices
-func (o I12StructWithMapOfSlices) DeepCopy() I12StructWithMapOfSlices {
-	var cp I12StructWithMapOfSlices = o
-	if o.Sc1 != nil {
-		cp.Sc1 = make(map[string][]I12StructWithSlices, len(o.Sc1))
-		for k2, v2 := range o.Sc1 {
-			var cp_Sc1_v2 []I12StructWithSlices
+	if o.Grouped != nil {
+		cp.Grouped = make(map[string][]valpkg.Val, len(o.Grouped))
+		for k2, v2 := range o.Grouped {
+			var cp_Grouped_v2 []valpkg.Val
 			if v2 != nil {
-				cp_Sc1_v2 = make([]I12StructWithSlices, len(v2))
-				copy(cp_Sc1_v2, v2)
+				cp_Grouped_v2 = make([]valpkg.Val, len(v2))
+				copy(cp_Grouped_v2, v2)
 				for i3 := range v2 {
-					if v2[i3].Name != nil {
-						cp_Sc1_v2[i3].Name = make([]string, len(v2[i3].Name))
-						copy(cp_Sc1_v2[i3].Name, v2[i3].Name)
+					if v2[i3].Data != nil {
+						cp_Grouped_v2[i3].Data = make([]byte, len(v2[i3].Data))
+						copy(cp_Grouped_v2[i3].Data, v2[i3].Data)
 					}
 				}
 			}
-			cp.Sc1[k2] = cp_Sc1_v2
+			cp.Grouped[k2] = cp_Grouped_v2
+		}
+	}
+	if o.Legacy != nil {
+		cp.Legacy = make(map[keypkg.Key][2]*github_com_globusdigital_deep_copy_testdata_remote_legacy_valpkg.Val, len(o.Legacy))
+		for k2, v2 := range o.Legacy {
+			var cp_Legacy_v2 [2]*github_com_globusdigital_deep_copy_testdata_remote_legacy_valpkg.Val = v2
+			for i3 := range v2 {
+				if v2[i3] != nil {
+					cp_Legacy_v2[i3] = new(github_com_globusdigital_deep_copy_testdata_remote_legacy_valpkg.Val)
+					*cp_Legacy_v2[i3] = *v2[i3]
+					if v2[i3].Tags != nil {
+						cp_Legacy_v2[i3].Tags = make([]string, len(v2[i3].Tags))
+						copy(cp_Legacy_v2[i3].Tags, v2[i3].Tags)
+					}
+				}
+			}
+			cp.Legacy[k2] = cp_Legacy_v2
+		}
+	}
+	if o.Pending != nil {
+		cp.Pending = make([]struct {
+			Key keypkg.Key
+			Val *github_com_globusdigital_deep_copy_testdata_remote_legacy_valpkg.Val
+		}, len(o.Pending))
+		copy(cp.Pending, o.Pending)
+		for i2 := range o.Pending {
+			if o.Pending[i2].Val != nil {
+				cp.Pending[i2].Val = new(github_com_globusdigital_deep_copy_testdata_remote_legacy_valpkg.Val)
+				*cp.Pending[i2].Val = *o.Pending[i2].Val
+				if o.Pending[i2].Val.Tags != nil {
+					cp.Pending[i2].Val.Tags = make([]string, len(o.Pending[i2].Val.Tags))
+					copy(cp.Pending[i2].Val.Tags, o.Pending[i2].Val.Tags)
+				}
+			}
 		}
 	}
 	return cp
 }`
+	TypePatternsFile = `// generated by deep-copy; DO NOT EDIT.
 
-	I15ParentHasChildValueValueRecv = `// generated by deep-copy; DO NOT EDIT.
+package all
 
-package testdata
+// DeepCopy generates a deep copy of Items
+func (o Items) DeepCopy() Items {
+	var cp Items = o
+	if o != nil {
+		cp = make([]Item, len(o))
+		copy(cp, o)
+		for i := range o {
+			cp[i] = o[i].DeepCopy()
+		}
+	}
+	return cp
+}
 
-// DeepCopy generates a deep copy of ParentHasChildValue
-func (o ParentHasChildValue) DeepCopy() ParentHasChildValue {
-	var cp ParentHasChildValue = o
-	cp.c = o.c.DeepCopy()
+// DeepCopy generates a deep copy of Catalog
+func (o Catalog) DeepCopy() Catalog {
+	var cp Catalog = o
+	cp.Items = o.Items.DeepCopy()
+	cp.Index = o.Index.DeepCopy()
+	if o.Owners != nil {
+		cp.Owners = make([]string, len(o.Owners))
+		copy(cp.Owners, o.Owners)
+	}
 	return cp
 }
 
-// DeepCopy generates a deep copy of Child
-func (o Child) DeepCopy() Child {
-	var cp Child = o
+// DeepCopy generates a deep copy of Item
+func (o Item) DeepCopy() Item {
+	var cp Item = o
 	return cp
-}`
+}
 
-	I15ParentHasChildPointerValueRecv = `// generated by deep-copy; DO NOT EDIT.
+// DeepCopy generates a deep copy of Index
+func (o Index) DeepCopy() Index {
+	var cp Index = o
+	if o != nil {
+		cp = make(Index, len(o))
+		for k, v := range o {
+			var cp_v *Item
+			if v != nil {
+				retV := v.DeepCopy()
+				cp_v = &retV
+			}
+			cp[k] = cp_v
+		}
+	}
+	return cp
+}`
+	TwiceFile = `// generated by deep-copy; DO NOT EDIT.
 
 package testdata
 
-// DeepCopy generates a deep copy of ParentHasChildPointer
-func (o ParentHasChildPointer) DeepCopy() ParentHasChildPointer {
-	var cp ParentHasChildPointer = o
-	if o.c != nil {
-		retV := o.c.DeepCopy()
-		cp.c = &retV
+// DeepCopy generates a deep copy of Twice
+func (o Twice) DeepCopy() Twice {
+	var cp Twice = o
+	if o.PP != nil {
+		cp.PP = new(*Leaf)
+		*cp.PP = *o.PP
+		if *o.PP != nil {
+			retV := (*o.PP).DeepCopy()
+			*cp.PP = &retV
+		}
 	}
 	return cp
 }
 
-// DeepCopy generates a deep copy of Child
-func (o Child) DeepCopy() Child {
-	var cp Child = o
+// DeepCopy generates a deep copy of Leaf
+func (o Leaf) DeepCopy() Leaf {
+	var cp Leaf = o
+	if o.Tags != nil {
+		cp.Tags = make([]string, len(o.Tags))
+		copy(cp.Tags, o.Tags)
+	}
 	return cp
 }`
-
-	I15ParentHasChildValuePointerRecv = `// generated by deep-copy; DO NOT EDIT.
+	TwiceAllocatorFile = `// generated by deep-copy; DO NOT EDIT.
 
 package testdata
 
-// DeepCopy generates a deep copy of *ParentHasChildValue
-func (o *ParentHasChildValue) DeepCopy() *ParentHasChildValue {
-	var cp ParentHasChildValue = *o
-	{
-		retV := o.c.DeepCopy()
-		cp.c = *retV
+// DeepCopy generates a deep copy of Twice
+func (o Twice) DeepCopy() Twice {
+	var cp Twice = o
+	if o.PP != nil {
+		cp.PP = new(*Leaf)
+		*cp.PP = *o.PP
+		if *o.PP != nil {
+			retV := (*o.PP).DeepCopy()
+			*cp.PP = &retV
+		}
 	}
-	return &cp
+	return cp
 }
 
-// DeepCopy generates a deep copy of *Child
-func (o *Child) DeepCopy() *Child {
-	var cp Child = *o
-	return &cp
-}`
+// DeepCopyWith generates a deep copy of Twice, allocated by the allocator
+func (o Twice) DeepCopyWith(allocator TwiceAllocator) Twice {
+	var cp Twice = o
+	if o.PP != nil {
+		cp.PP = allocator.NewLeafPtr()
+		*cp.PP = *o.PP
+		if *o.PP != nil {
+			*cp.PP = allocator.NewLeaf()
+			**cp.PP = (*o.PP).DeepCopyWith(allocator)
+		}
+	}
+	return cp
+}
 
-	I15ParentHasChildPointerPointerRecv = `// generated by deep-copy; DO NOT EDIT.
+// DeepCopy generates a deep copy of Leaf
+func (o Leaf) DeepCopy() Leaf {
+	var cp Leaf = o
+	if o.Tags != nil {
+		cp.Tags = make([]string, len(o.Tags))
+		copy(cp.Tags, o.Tags)
+	}
+	return cp
+}
+
+// DeepCopyWith generates a deep copy of Leaf, allocated by the allocator
+func (o Leaf) DeepCopyWith(allocator LeafAllocator) Leaf {
+	var cp Leaf = o
+	if o.Tags != nil {
+		cp.Tags = allocator.MakeStringSlice(len(o.Tags))
+		copy(cp.Tags, o.Tags)
+	}
+	return cp
+}
+
+// TwiceAllocator allocates the values copied by Twice.DeepCopyWith
+type TwiceAllocator interface {
+	MakeStringSlice(len int) []string
+	NewLeaf() *Leaf
+	NewLeafPtr() **Leaf
+}
+
+// LeafAllocator allocates the values copied by Leaf.DeepCopyWith
+type LeafAllocator interface {
+	MakeStringSlice(len int) []string
+}`
+	TwiceCloneFile = `// generated by deep-copy; DO NOT EDIT.
 
 package testdata
 
-// DeepCopy generates a deep copy of *ParentHasChildPointer
-func (o *ParentHasChildPointer) DeepCopy() *ParentHasChildPointer {
-	var cp ParentHasChildPointer = *o
-	if o.c != nil {
-		cp.c = o.c.DeepCopy()
+// Clone generates a deep copy of Twice
+func (o Twice) Clone() Twice {
+	var cp Twice = o
+	if o.PP != nil {
+		cp.PP = new(*Leaf)
+		*cp.PP = *o.PP
+		if *o.PP != nil {
+			retV := (*o.PP).Clone()
+			*cp.PP = &retV
+		}
 	}
-	return &cp
+	return cp
 }
 
-// DeepCopy generates a deep copy of *Child
-func (o *Child) DeepCopy() *Child {
-	var cp Child = *o
-	return &cp
+// Clone generates a deep copy of Leaf
+func (o Leaf) Clone() Leaf {
+	var cp Leaf = o
+	if o.Tags != nil {
+		cp.Tags = make([]string, len(o.Tags))
+		copy(cp.Tags, o.Tags)
+	}
+	return cp
 }`
-
-	Issue17MaxDepth = `// generated by deep-copy; DO NOT EDIT.
+	SkipAllMapValuesFile = `// generated by deep-copy; DO NOT EDIT.
 
 package testdata
 
-// DeepCopy generates a deep copy of *Depth1
-func (o *Depth1) DeepCopy() *Depth1 {
-	var cp Depth1 = *o
-	if o.a1 != nil {
-		cp.a1 = new(Depth2)
-		*cp.a1 = *o.a1
+// DeepCopy generates a deep copy of Worker
+func (o Worker) DeepCopy() Worker {
+	var cp Worker = o
+	if o.Queue != nil {
+		cp.Queue = make(map[string]*int, len(o.Queue))
+		for k2, v2 := range o.Queue {
+			cp.Queue[k2] = v2
+		}
 	}
-	if o.a2 != nil {
-		cp.a2 = new(Depth2)
-		*cp.a2 = *o.a2
+	if o.logger != nil {
+		cp.logger = new(Logger)
+		*cp.logger = *o.logger
+		if o.logger.prefix != nil {
+			cp.logger.prefix = make([]string, len(o.logger.prefix))
+			copy(cp.logger.prefix, o.logger.prefix)
+		}
 	}
-	return &cp
+	if o.Nested.logger != nil {
+		cp.Nested.logger = new(Logger)
+		*cp.Nested.logger = *o.Nested.logger
+		if o.Nested.logger.prefix != nil {
+			cp.Nested.logger.prefix = make([]string, len(o.Nested.logger.prefix))
+			copy(cp.Nested.logger.prefix, o.Nested.logger.prefix)
+		}
+	}
+	if o.Nested.Tags != nil {
+		cp.Nested.Tags = make([]string, len(o.Nested.Tags))
+		copy(cp.Nested.Tags, o.Nested.Tags)
+	}
+	return cp
 }`
 )
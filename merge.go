@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"strconv"
+)
+
+// mergeFile merges the generated file content into the existing content of
+// the output file. Functions and methods of the existing file that are also
+// present in the generated content are replaced by their new version, while
+// any other declarations are kept. The imports of both files are merged, and
+// the header of the generated content is used.
+func mergeFile(existing, generated []byte) ([]byte, error) {
+	if len(bytes.TrimSpace(existing)) == 0 {
+		return generated, nil
+	}
+
+	fset := token.NewFileSet()
+	old, err := parser.ParseFile(fset, "existing.go", existing, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parsing existing file: %v", err)
+	}
+	gen, err := parser.ParseFile(fset, "generated.go", generated, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parsing generated file: %v", err)
+	}
+
+	if old.Name.Name != gen.Name.Name {
+		return nil, fmt.Errorf("existing file is in package %q, not %q", old.Name.Name, gen.Name.Name)
+	}
+
+	var imports []importSpec
+	seen := map[importSpec]struct{}{}
+	names := map[string]string{}
+	for _, f := range []*ast.File{old, gen} {
+		for _, spec := range f.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			imp := importSpec{path: importPath}
+			if spec.Name != nil {
+				imp.name = spec.Name.Name
+			}
+
+			if _, ok := seen[imp]; ok {
+				continue
+			}
+			seen[imp] = struct{}{}
+			imports = append(imports, imp)
+
+			name := imp.name
+			if name == "" {
+				name = path.Base(importPath)
+			}
+			if name == "_" || name == "." {
+				continue
+			}
+			if other, ok := names[name]; ok && other != importPath {
+				return nil, fmt.Errorf("conflicting imports %q and %q for name %s", other, importPath, name)
+			}
+			names[name] = importPath
+		}
+	}
+
+	// A package imported for its side effects only, that is now imported
+	// by name as well, needs no blank import anymore.
+	byName := map[string]bool{}
+	for _, imp := range imports {
+		if imp.name != "_" {
+			byName[imp.path] = true
+		}
+	}
+	kept := imports[:0]
+	for _, imp := range imports {
+		if imp.name != "_" || !byName[imp.path] {
+			kept = append(kept, imp)
+		}
+	}
+	imports = kept
+
+	generatedFuncs := map[string]struct{}{}
+	for _, decl := range gen.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			generatedFuncs[funcKey(decl)] = struct{}{}
+		case *ast.GenDecl:
+			// Such as the options of DeepCopyOpts methods.
+			if key := typeKey(decl); key != "" {
+				generatedFuncs[key] = struct{}{}
+			}
+		}
+	}
+
+	var file bytes.Buffer
+
+	file.Write(generated[:fset.Position(gen.Package).Offset])
+	fmt.Fprintf(&file, "package %s\n\n", gen.Name.Name)
+
+	if len(imports) > 0 {
+		file.WriteString("import (\n")
+		for _, imp := range imports {
+			if imp.name != "" {
+				fmt.Fprintf(&file, "%s %q\n", imp.name, imp.path)
+			} else {
+				fmt.Fprintf(&file, "%q\n", imp.path)
+			}
+		}
+		file.WriteString(")\n")
+	}
+
+	writeDecls := func(f *ast.File, src []byte, skip map[string]struct{}) {
+		for _, decl := range f.Decls {
+			var start token.Pos
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				if decl.Tok == token.IMPORT {
+					continue
+				}
+				if _, ok := skip[typeKey(decl)]; ok {
+					continue
+				}
+				start = decl.Pos()
+				if decl.Doc != nil {
+					start = decl.Doc.Pos()
+				}
+			case *ast.FuncDecl:
+				if _, ok := skip[funcKey(decl)]; ok {
+					continue
+				}
+				start = decl.Pos()
+				if decl.Doc != nil {
+					start = decl.Doc.Pos()
+				}
+			}
+
+			file.WriteString("\n")
+			file.Write(src[fset.Position(start).Offset:fset.Position(decl.End()).Offset])
+			file.WriteString("\n")
+		}
+	}
+
+	writeDecls(old, existing, generatedFuncs)
+	writeDecls(gen, generated, nil)
+
+	b, err := format.Source(file.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting merged source: %w\nsource:\n%s", err, file.String())
+	}
+
+	return b, nil
+}
+
+type importSpec struct {
+	name, path string
+}
+
+// funcKey returns the name of the function, prefixed by its receiver's type
+// name for methods.
+func funcKey(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+
+	recv := fn.Recv.List[0].Type
+	for {
+		switch t := recv.(type) {
+		case *ast.StarExpr:
+			recv = t.X
+			continue
+		case *ast.IndexExpr:
+			recv = t.X
+			continue
+		case *ast.IndexListExpr:
+			recv = t.X
+			continue
+		case *ast.Ident:
+			return t.Name + "." + fn.Name.Name
+		}
+
+		return fn.Name.Name
+	}
+}
+
+// typeKey returns the key of a declaration of a single type, prefixed so as not
+// to collide with the function keys, or an empty string for other declarations.
+func typeKey(decl *ast.GenDecl) string {
+	if decl.Tok != token.TYPE || len(decl.Specs) != 1 {
+		return ""
+	}
+
+	return "type " + decl.Specs[0].(*ast.TypeSpec).Name.Name
+}
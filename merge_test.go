@@ -0,0 +1,244 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_mergeFile(t *testing.T) {
+	a := &app{}
+
+	foo, err := a.run("./testdata", typesVal{"Foo"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alpha, err := a.run("./testdata", typesVal{"Alpha"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := mergeFile(nil, foo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = mergeFile(got, alpha)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fn := range []string{"func (o Foo) DeepCopy() Foo", "func (o Alpha) DeepCopy() Alpha"} {
+		if n := strings.Count(string(got), fn); n != 1 {
+			t.Errorf("merged file has %d definitions of %q, want 1:\n%s", n, fn, got)
+		}
+	}
+
+	a.isPtrRecv = true
+	fooPtr, err := a.run("./testdata", typesVal{"Foo"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = mergeFile(got, fooPtr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(got), "func (o Foo) DeepCopy() Foo") {
+		t.Errorf("merged file still has the replaced method:\n%s", got)
+	}
+	for _, fn := range []string{"func (o *Foo) DeepCopy() *Foo", "func (o Alpha) DeepCopy() Alpha"} {
+		if n := strings.Count(string(got), fn); n != 1 {
+			t.Errorf("merged file has %d definitions of %q, want 1:\n%s", n, fn, got)
+		}
+	}
+}
+
+func Test_mergeFile_runtimeOpts(t *testing.T) {
+	a := &app{runtimeOpts: true, optional: []string{"Cache"}}
+
+	generated, err := a.run("./testdata", typesVal{"Session"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := mergeFile(generated, generated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, decl := range []string{"type SessionCopyOptions struct", "func (o Session) DeepCopyOpts(opts SessionCopyOptions) Session"} {
+		if n := strings.Count(string(got), decl); n != 1 {
+			t.Errorf("merged file has %d definitions of %q, want 1:\n%s", n, decl, got)
+		}
+	}
+}
+
+func Test_mergeFile_imports(t *testing.T) {
+	existing := []byte(`// generated by deep-copy; DO NOT EDIT.
+
+package pkg
+
+import (
+	"a/b"
+)
+
+// DeepCopy generates a deep copy of T
+func (o T) DeepCopy() T {
+	return o
+}
+`)
+
+	tests := []struct {
+		name      string
+		generated string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name: "merged",
+			generated: `// generated by deep-copy; DO NOT EDIT.
+
+package pkg
+
+import (
+	c "x/c"
+)
+
+// DeepCopy generates a deep copy of U
+func (o U) DeepCopy() U {
+	return o
+}
+`,
+			want: `// generated by deep-copy; DO NOT EDIT.
+
+package pkg
+
+import (
+	"a/b"
+	c "x/c"
+)
+
+// DeepCopy generates a deep copy of T
+func (o T) DeepCopy() T {
+	return o
+}
+
+// DeepCopy generates a deep copy of U
+func (o U) DeepCopy() U {
+	return o
+}
+`,
+		},
+		{
+			name: "blank import of a named one",
+			generated: `// generated by deep-copy; DO NOT EDIT.
+
+package pkg
+
+import (
+	_ "a/b"
+	_ "x/d"
+)
+`,
+			want: `// generated by deep-copy; DO NOT EDIT.
+
+package pkg
+
+import (
+	"a/b"
+	_ "x/d"
+)
+
+// DeepCopy generates a deep copy of T
+func (o T) DeepCopy() T {
+	return o
+}
+`,
+		},
+		{
+			name: "conflicting names",
+			generated: `// generated by deep-copy; DO NOT EDIT.
+
+package pkg
+
+import (
+	b "x/b"
+)
+`,
+			wantErr: true,
+		},
+		{
+			name: "different package",
+			generated: `// generated by deep-copy; DO NOT EDIT.
+
+package other
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeFile(existing, []byte(tt.generated))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("mergeFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("mergeFile() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mergeFile_stale(t *testing.T) {
+	existing, err := os.ReadFile("./testdata/stale_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := &app{isPtrRecv: true, output: "./testdata/stale_gen.go"}
+	generated, err := a.run("./testdata", typesVal{"Stale"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := mergeFile(existing, generated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got = normalizeComment(got)
+	if diff := cmp.Diff(string(got), StaleRegenerated); diff != "" {
+		t.Errorf("mergeFile() diff = %s", diff)
+	}
+}
+
+const StaleRegenerated = `// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of *Stale
+func (o *Stale) DeepCopy() *Stale {
+	var cp Stale = *o
+	if o.Items != nil {
+		cp.Items = make([]string, len(o.Items))
+		copy(cp.Items, o.Items)
+	}
+	if o.Next != nil {
+		cp.Next = o.Next.DeepCopy()
+	}
+	if o.Added != nil {
+		cp.Added = make(map[string][]int, len(o.Added))
+		for k2, v2 := range o.Added {
+			var cp_Added_v2 []int
+			if v2 != nil {
+				cp_Added_v2 = make([]int, len(v2))
+				copy(cp_Added_v2, v2)
+			}
+			cp.Added[k2] = cp_Added_v2
+		}
+	}
+	return &cp
+}`
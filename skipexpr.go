@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// skipExpr is a boolean expression of the selector of a member, such as
+// `field.endsWith("Cache")`, shallow copying the members it holds for.
+//
+// Expressions are written in the Go syntax. The selector, in the form of the
+// -skip flag, is the string field, which can be compared with == and !=, or
+// matched with its endsWith, startsWith, contains and matches methods, given a
+// string literal. Conditions are combined with &&, || and !.
+type skipExpr struct {
+	src   string
+	match func(field string) bool
+}
+
+// parseSkipExpr parses the expression, reporting any unsupported construct.
+func parseSkipExpr(src string) (*skipExpr, error) {
+	e, err := parser.ParseExpr(src)
+	if err != nil {
+		return nil, fmt.Errorf("parsing skip expression %q: %v", src, err)
+	}
+
+	match, err := compileBool(e)
+	if err != nil {
+		return nil, fmt.Errorf("invalid skip expression %q: %v", src, err)
+	}
+
+	return &skipExpr{src: src, match: match}, nil
+}
+
+// Match reports whether the expression holds for the selector.
+func (e *skipExpr) Match(sel string) bool {
+	return e.match(sel)
+}
+
+func (e *skipExpr) String() string {
+	return e.src
+}
+
+// compileBool compiles a boolean expression of the field.
+func compileBool(e ast.Expr) (func(string) bool, error) {
+	switch e := e.(type) {
+	case *ast.ParenExpr:
+		return compileBool(e.X)
+	case *ast.UnaryExpr:
+		if e.Op != token.NOT {
+			return nil, fmt.Errorf("unsupported operator %s", e.Op)
+		}
+
+		x, err := compileBool(e.X)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(field string) bool { return !x(field) }, nil
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.LAND, token.LOR:
+			x, err := compileBool(e.X)
+			if err != nil {
+				return nil, err
+			}
+			y, err := compileBool(e.Y)
+			if err != nil {
+				return nil, err
+			}
+
+			if e.Op == token.LAND {
+				return func(field string) bool { return x(field) && y(field) }, nil
+			}
+			return func(field string) bool { return x(field) || y(field) }, nil
+		case token.EQL, token.NEQ:
+			x, err := compileString(e.X)
+			if err != nil {
+				return nil, err
+			}
+			y, err := compileString(e.Y)
+			if err != nil {
+				return nil, err
+			}
+
+			if e.Op == token.EQL {
+				return func(field string) bool { return x(field) == y(field) }, nil
+			}
+			return func(field string) bool { return x(field) != y(field) }, nil
+		}
+
+		return nil, fmt.Errorf("unsupported operator %s", e.Op)
+	case *ast.CallExpr:
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return nil, fmt.Errorf("unsupported call of %s", types.ExprString(e.Fun))
+		}
+
+		x, err := compileString(sel.X)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(e.Args) != 1 {
+			return nil, fmt.Errorf("%s takes a single argument", sel.Sel.Name)
+		}
+		arg, err := stringLit(e.Args[0])
+		if err != nil {
+			return nil, fmt.Errorf("argument of %s: %v", sel.Sel.Name, err)
+		}
+
+		switch sel.Sel.Name {
+		case "endsWith":
+			return func(field string) bool { return strings.HasSuffix(x(field), arg) }, nil
+		case "startsWith":
+			return func(field string) bool { return strings.HasPrefix(x(field), arg) }, nil
+		case "contains":
+			return func(field string) bool { return strings.Contains(x(field), arg) }, nil
+		case "matches":
+			re, err := regexp.Compile(arg)
+			if err != nil {
+				return nil, fmt.Errorf("argument of matches: %v", err)
+			}
+
+			return func(field string) bool { return re.MatchString(x(field)) }, nil
+		}
+
+		return nil, fmt.Errorf("unknown method %s", sel.Sel.Name)
+	}
+
+	return nil, fmt.Errorf("%s is not a condition", types.ExprString(e))
+}
+
+// compileString compiles a string expression, either the field or a literal.
+func compileString(e ast.Expr) (func(string) string, error) {
+	switch e := e.(type) {
+	case *ast.ParenExpr:
+		return compileString(e.X)
+	case *ast.Ident:
+		if e.Name != "field" {
+			return nil, fmt.Errorf("unknown identifier %s", e.Name)
+		}
+
+		return func(field string) string { return field }, nil
+	case *ast.BasicLit:
+		s, err := stringLit(e)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(string) string { return s }, nil
+	}
+
+	return nil, fmt.Errorf("%s is not a string", types.ExprString(e))
+}
+
+// stringLit returns the value of a string literal.
+func stringLit(e ast.Expr) (string, error) {
+	lit, ok := e.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", fmt.Errorf("%s is not a string literal", types.ExprString(e))
+	}
+
+	return strconv.Unquote(lit.Value)
+}
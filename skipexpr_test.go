@@ -0,0 +1,73 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_skipExpr(t *testing.T) {
+	tests := []struct {
+		expr string
+		want map[string]bool
+	}{
+		{expr: `field.endsWith("Cache")`, want: map[string]bool{"Cache": true, "Inner.Cache": true, "Caches": false, "": false}},
+		{expr: `field.startsWith("Map[k]")`, want: map[string]bool{"Map[k]": true, "Map[k].Slice": true, "Map": false}},
+		{expr: `field.contains("[i]")`, want: map[string]bool{"Slice[i]": true, "Slice[i].Ptr": true, "Map[k]": false}},
+		{expr: `field.matches("^[a-z]")`, want: map[string]bool{"logger": true, "Inner.logger": false}},
+		{expr: `field == "logger" || field.endsWith(".logger")`, want: map[string]bool{"logger": true, "Deps.logger": true, "mylogger": false}},
+		{expr: `field != "Keep" && !(field.startsWith("Keep."))`, want: map[string]bool{"Keep": false, "Keep.Slice": false, "Other": true}},
+		{expr: `("Cache") == field`, want: map[string]bool{"Cache": true, "Inner.Cache": false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			e, err := parseSkipExpr(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for field, want := range tt.want {
+				if got := e.Match(field); got != want {
+					t.Errorf("Match(%q) = %v, want %v", field, got, want)
+				}
+			}
+		})
+	}
+}
+
+func Test_skipExprErrors(t *testing.T) {
+	for _, expr := range []string{
+		`field.endsWith(`,
+		`field`,
+		`"Cache"`,
+		`name == "Cache"`,
+		`field.endsWith(field)`,
+		`field.endsWith("a", "b")`,
+		`field.hasSuffix("Cache")`,
+		`field.matches("[")`,
+		`endsWith("Cache")`,
+		`field + "x" == "Cachex"`,
+		`-field`,
+	} {
+		if _, err := parseSkipExpr(expr); err == nil {
+			t.Errorf("parseSkipExpr(%q) = nil error, want one", expr)
+		}
+	}
+}
+
+func Test_run_skipExpr(t *testing.T) {
+	e, err := parseSkipExpr(`field == "logger" || field.endsWith(".logger")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := &app{skipExpr: e}
+	got, err := a.run("./testdata", typesVal{"Service", "Worker"}, skipsVal{{"Deps": struct{}{}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(string(normalizeComment(got)), SkipAllLogger); diff != "" {
+		t.Errorf("generateFile() diff = %s", diff)
+	}
+}
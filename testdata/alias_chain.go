@@ -0,0 +1,20 @@
+package testdata
+
+import "github.com/globusdigital/deep-copy/testdata/aliases"
+
+type (
+	Stamp    = aliases.Timestamp
+	LabelSet = aliases.Labels
+	NameList = aliases.Names
+)
+
+type Audited struct {
+	Created  Stamp
+	Updated  []Stamp
+	Labels   LabelSet
+	History  []LabelSet
+	Previous *Stamp
+	Names    []NameList
+	Aliases  *NameList
+	Extra    *aliases.Labels
+}
@@ -0,0 +1,13 @@
+package aliases
+
+import (
+	"time"
+
+	"github.com/globusdigital/deep-copy/testdata/containers"
+)
+
+type Timestamp = time.Time
+
+type Labels = map[string][]string
+
+type Names = containers.List[string]
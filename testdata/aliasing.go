@@ -0,0 +1,14 @@
+package testdata
+
+type Waypoint struct {
+	Name string
+	Tags []string
+}
+
+// Route points at the same waypoints more than once, such as its Start and
+// the first of its Stops.
+type Route struct {
+	Start, End *Waypoint
+	Stops      []*Waypoint
+	Named      map[string]*Waypoint
+}
@@ -0,0 +1,42 @@
+package all
+
+import "io"
+
+type Item struct {
+	Name  string
+	Count int
+}
+
+type Items []Item
+
+type Index map[string]*Item
+
+type Catalog struct {
+	Items  Items
+	Index  Index
+	Owners []string
+}
+
+// Manual declares its own DeepCopy method, so it isn't generated.
+type Manual struct {
+	Data []byte
+}
+
+func (m Manual) DeepCopy() Manual {
+	return Manual{Data: append([]byte(nil), m.Data...)}
+}
+
+type Shelf struct {
+	Manual  Manual
+	Catalog *Catalog
+}
+
+type Entry = Item
+
+type ID int
+
+type Source io.Reader
+
+type draft struct {
+	Items Items
+}
@@ -0,0 +1,8 @@
+package testdata
+
+type Allocated struct {
+	Items  []*PtrItem
+	Index  map[string][]int
+	Child  *Allocated
+	Values []Allocated
+}
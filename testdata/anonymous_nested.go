@@ -0,0 +1,19 @@
+package testdata
+
+import (
+	"github.com/globusdigital/deep-copy/testdata/generic"
+	"github.com/globusdigital/deep-copy/testdata/pointer_that_implements_deepcopy/anotherpkg"
+)
+
+type AnonymousNested struct {
+	Outer struct {
+		Name  string
+		Inner struct {
+			Boxes   []generic.Box[string]
+			Structs []*anotherpkg.AnotherStruct
+		}
+		Inners []struct {
+			Boxes map[string][]generic.Box[int]
+		}
+	}
+}
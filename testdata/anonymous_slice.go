@@ -0,0 +1,11 @@
+package testdata
+
+type TaggedItems struct {
+	Items []struct {
+		Name string
+		Tags []string `json:"tags"`
+	}
+	Refs []*struct {
+		Tags []string
+	}
+}
@@ -0,0 +1,25 @@
+package testdata
+
+type AppError interface {
+	error
+	Code() int
+}
+
+type CopiedError interface {
+	error
+	DeepCopy() CopiedError
+}
+
+type Failure struct {
+	Err    AppError
+	Causes []AppError
+	Copied CopiedError
+}
+
+type CodeError struct {
+	Codes []int
+}
+
+func (e *CodeError) Error() string { return "code error" }
+
+func (e *CodeError) Code() int { return e.Codes[0] }
@@ -0,0 +1,5 @@
+package testdata
+
+type PairIndex struct {
+	Pairs map[[2]*Bar][]string
+}
@@ -0,0 +1,9 @@
+package testdata
+
+import "embed"
+
+type Assets struct {
+	Files    embed.FS
+	Bundles  map[string]embed.FS
+	Patterns []string
+}
@@ -0,0 +1,6 @@
+package testdata
+
+type Batch struct {
+	Items  []*Kid
+	Groups [][]*Kid
+}
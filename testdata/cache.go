@@ -0,0 +1,12 @@
+package testdata
+
+// Cache holds values of any type, which are shared by its copies.
+type Cache[V any] struct {
+	Entries map[string]V
+}
+
+// CopierCache holds values deep copied by the DeepCopy method of their
+// constraint.
+type CopierCache[V interface{ DeepCopy() V }] struct {
+	Entries map[string]V
+}
@@ -0,0 +1,5 @@
+package testdata
+
+type Callbacks struct {
+	OnEvent map[string]func()
+}
@@ -0,0 +1,10 @@
+package testdata
+
+type Bytes []byte
+
+type Buffers struct {
+	Raw    []byte
+	Ints   []int
+	Named  Bytes
+	Nested [][]byte
+}
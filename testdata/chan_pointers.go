@@ -0,0 +1,6 @@
+package testdata
+
+type ChanPointers struct {
+	Signal *chan int
+	Recv   *<-chan int
+}
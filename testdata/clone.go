@@ -0,0 +1,30 @@
+package testdata
+
+// Snippet predates the generated copies, its DeepCopy method being a shallow
+// clone.
+type Snippet struct {
+	Lines []string
+}
+
+func (s Snippet) DeepCopy() Snippet {
+	return s
+}
+
+type Version struct {
+	Parts []int
+}
+
+func (v *Version) Clone() *Version {
+	cp := &Version{}
+	if v.Parts != nil {
+		cp.Parts = append([]int(nil), v.Parts...)
+	}
+	return cp
+}
+
+type Document struct {
+	Title   string
+	Body    Snippet
+	Version *Version
+	Notes   []Snippet
+}
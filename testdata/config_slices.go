@@ -0,0 +1,8 @@
+package testdata
+
+type Config map[string]string
+
+type Configs struct {
+	Layers []Config
+	ByEnv  map[string][]Config
+}
@@ -0,0 +1,10 @@
+package testdata
+
+import "github.com/globusdigital/deep-copy/testdata/containers"
+
+type Inventory struct {
+	Stock  containers.OrderedMap[string, []int]
+	Owners containers.OrderedMap[string, *Kid]
+	Names  containers.List[string]
+	Lists  []containers.List[*Foo]
+}
@@ -0,0 +1,15 @@
+package containers
+
+type OrderedMap[K comparable, V any] map[K]V
+
+type List[T any] []T
+
+func (l List[T]) DeepCopy() List[T] {
+	if l == nil {
+		return nil
+	}
+
+	cp := make(List[T], len(l))
+	copy(cp, l)
+	return cp
+}
@@ -0,0 +1,12 @@
+package testdata
+
+type Crate[T any] struct {
+	Items []T
+	Label *string
+}
+
+type Crates struct {
+	Ints    Crate[int]
+	Strings Crate[string]
+	Kids    []Crate[*Kid]
+}
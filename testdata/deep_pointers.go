@@ -0,0 +1,5 @@
+package testdata
+
+type BarIndex struct {
+	Groups *map[string][]*Bar
+}
@@ -0,0 +1,9 @@
+package testdata
+
+type RecvOnly <-chan int
+
+type Directed struct {
+	In   RecvOnly
+	Out  chan<- string
+	Both chan int
+}
@@ -0,0 +1,10 @@
+package testdata
+
+type Leaf struct {
+	Tags []string
+}
+
+// Twice points at its Leaf through two pointers.
+type Twice struct {
+	PP **Leaf
+}
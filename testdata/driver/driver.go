@@ -0,0 +1,16 @@
+// Package driver stands for a database driver, which is usually imported for
+// its side effects only.
+package driver
+
+// Registered reports whether the driver has been registered.
+var Registered bool
+
+func init() {
+	Registered = true
+}
+
+type Array []string
+
+type Options struct {
+	Params map[string]string
+}
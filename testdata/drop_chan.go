@@ -0,0 +1,7 @@
+package testdata
+
+type DropChan struct {
+	Done   chan struct{}
+	Queues []chan int
+	ByName map[string]chan int
+}
@@ -0,0 +1,30 @@
+package testdata
+
+type Figure interface {
+	Area() float64
+}
+
+type Polygon struct {
+	Sides []float64
+}
+
+func (p *Polygon) Area() float64 { return 0 }
+
+func (p *Polygon) DeepCopy() *Polygon {
+	cp := *p
+	cp.Sides = append([]float64(nil), p.Sides...)
+	return &cp
+}
+
+type Circle struct {
+	R float64
+}
+
+func (c Circle) Area() float64 { return 3 * c.R * c.R }
+
+type Sketch struct {
+	Main    Figure
+	Figures []Figure
+	Meta    any
+	Err     error
+}
@@ -0,0 +1,36 @@
+package testdata
+
+type Event interface {
+	Name() string
+}
+
+type ClickEvent struct {
+	Tags []string
+}
+
+func (e ClickEvent) Name() string { return "click" }
+
+type KeyEvent struct {
+	Keys map[string]int
+}
+
+func (e *KeyEvent) Name() string { return "key" }
+
+type PlainEvent struct {
+	ID int
+}
+
+func (e PlainEvent) Name() string { return "plain" }
+
+type EventLog struct {
+	Events map[string][]Event
+	Last   Event
+}
+
+type EventRef struct {
+	Event *Event
+}
+
+type EventRing struct {
+	Recent [3]Event
+}
@@ -0,0 +1,12 @@
+package testdata
+
+import "github.com/globusdigital/deep-copy/testdata/unexportedpkg"
+
+type ExternalUnexported struct {
+	External unexportedpkg.WithUnexported
+}
+
+type EmbeddedExternal struct {
+	unexportedpkg.WithUnexported
+	Name string
+}
@@ -0,0 +1,12 @@
+package testdata
+
+type ArrayNode struct {
+	Name     string
+	Children [4]*ArrayNode
+}
+
+type FixedArrays struct {
+	Root    ArrayNode
+	Buffers [3][]byte
+	Counts  [8]int
+}
@@ -0,0 +1,6 @@
+package testdata
+
+type Fixture struct {
+	Inputs   []string
+	Expected map[string]*Foo
+}
@@ -17,3 +17,5 @@ type Baz struct {
 }
 
 type SlicePointer []*int
+
+type MapPointer map[string]*int
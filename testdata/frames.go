@@ -0,0 +1,19 @@
+package testdata
+
+type FrameHeader struct {
+	Tags []string
+}
+
+type Frame struct {
+	Data    []byte
+	Bars    []*Bar
+	Header  FrameHeader
+	Prev    *FrameHeader
+	Indexes map[string][]int
+}
+
+type Samples []float64
+
+type Track struct {
+	Frames []*Frame
+}
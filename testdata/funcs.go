@@ -0,0 +1,17 @@
+package testdata
+
+type Tree struct {
+	Root   *Node
+	Nodes  []Node
+	ByName map[string]*Node
+	hidden []int
+}
+
+type Node struct {
+	Name     string
+	Children []*Node
+}
+
+type hiddenTree struct {
+	nodes []Node
+}
@@ -0,0 +1,34 @@
+package testdata
+
+import (
+	"io"
+
+	"github.com/globusdigital/deep-copy/testdata/generic"
+)
+
+type FuncFields struct {
+	Make     func() []int
+	Consume  func(<-chan generic.Box[int]) error
+	Format   func(string, ...interface{}) string
+	Open     func(name string) (io.ReadCloser, error)
+	Handlers map[string]func(io.Writer) []byte
+	Hooks    []func(*FuncFields)
+}
+
+type Validators struct {
+	Validate func(x int) (bool, error)
+	Split    func(s string, seps ...rune) (head, tail string, ok bool)
+	Copy     func(dst io.Writer, srcs ...io.Reader) (written int64, err error)
+	Unbox    func(boxes ...generic.Box[int]) (generic.Box[string], []error)
+	Chain    func(func(int) (int, error)) func(...int) (int, error)
+	Checks   []func(x int) (bool, error)
+}
+
+type Handler func(int)
+
+type Dispatcher struct {
+	OnInt    Handler
+	Handlers []Handler
+	Named    map[string]Handler
+	Next     *Handler
+}
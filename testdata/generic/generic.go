@@ -0,0 +1,12 @@
+package generic
+
+type Box[T any] struct {
+	Items []T
+}
+
+type Pair[K, V any] struct {
+	First  K
+	Second V
+	Ptr    *K
+	Values map[string][]V
+}
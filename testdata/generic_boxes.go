@@ -0,0 +1,20 @@
+package testdata
+
+type Pack[T any] struct {
+	Items []T
+}
+
+func (p Pack[T]) DeepCopy() Pack[T] {
+	cp := p
+	if p.Items != nil {
+		cp.Items = make([]T, len(p.Items))
+		copy(cp.Items, p.Items)
+	}
+	return cp
+}
+
+type Packs struct {
+	Ints    []Pack[int]
+	Strings map[string]Pack[string]
+	Ptrs    []*Pack[int]
+}
@@ -0,0 +1,3 @@
+package testdata
+
+type Set[T comparable] map[T]struct{}
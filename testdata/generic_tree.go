@@ -0,0 +1,6 @@
+package testdata
+
+type GenericTree[T any] struct {
+	Value    T
+	Children []*GenericTree[T]
+}
@@ -0,0 +1,7 @@
+package testdata
+
+import "github.com/globusdigital/deep-copy/testdata/handlers"
+
+type HandlerChain struct {
+	Handlers []handlers.Handler
+}
@@ -0,0 +1,37 @@
+// generated by deep-copy -iface-impls github.com/globusdigital/deep-copy/testdata/handlers.Handler=github.com/globusdigital/deep-copy/testdata/handlers.Mux,*github.com/globusdigital/deep-copy/testdata/handlers/loggers.Logger -type HandlerChain -o handler_chain_gen.go .; DO NOT EDIT.
+
+package testdata
+
+import (
+	"github.com/globusdigital/deep-copy/testdata/handlers"
+	"github.com/globusdigital/deep-copy/testdata/handlers/loggers"
+)
+
+// DeepCopy generates a deep copy of HandlerChain
+func (o HandlerChain) DeepCopy() HandlerChain {
+	var cp HandlerChain = o
+	if o.Handlers != nil {
+		cp.Handlers = make([]handlers.Handler, len(o.Handlers))
+		copy(cp.Handlers, o.Handlers)
+		for i2 := range o.Handlers {
+			switch impl3 := o.Handlers[i2].(type) {
+			case handlers.Mux:
+				var cp_Handlers_i2_impl3 handlers.Mux = impl3
+				if impl3.Routes != nil {
+					cp_Handlers_i2_impl3.Routes = make(map[string]string, len(impl3.Routes))
+					for k5, v5 := range impl3.Routes {
+						cp_Handlers_i2_impl3.Routes[k5] = v5
+					}
+				}
+				cp.Handlers[i2] = cp_Handlers_i2_impl3
+			case *loggers.Logger:
+				var cp_Handlers_i2_impl3 *loggers.Logger
+				if impl3 != nil {
+					cp_Handlers_i2_impl3 = impl3.DeepCopy()
+				}
+				cp.Handlers[i2] = cp_Handlers_i2_impl3
+			}
+		}
+	}
+	return cp
+}
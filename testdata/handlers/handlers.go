@@ -0,0 +1,11 @@
+package handlers
+
+type Handler interface {
+	Handle(string)
+}
+
+type Mux struct {
+	Routes map[string]string
+}
+
+func (m Mux) Handle(string) {}
@@ -0,0 +1,15 @@
+package loggers
+
+type Logger struct {
+	Lines []string
+}
+
+func (l *Logger) Handle(line string) {
+	l.Lines = append(l.Lines, line)
+}
+
+func (l *Logger) DeepCopy() *Logger {
+	cp := &Logger{}
+	cp.Lines = append(cp.Lines, l.Lines...)
+	return cp
+}
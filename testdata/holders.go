@@ -0,0 +1,6 @@
+package testdata
+
+type Holder[T any] struct {
+	Items []T
+	Ptr   *T
+}
@@ -0,0 +1,7 @@
+package testdata
+
+import "fmt"
+
+type StringerIndex struct {
+	Bars map[fmt.Stringer]*Bar
+}
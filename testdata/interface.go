@@ -0,0 +1,30 @@
+package testdata
+
+type Cloner interface {
+	DeepCopy() Shape
+}
+
+type Shape interface {
+	Cloner
+	Area() float64
+}
+
+type Copier interface {
+	DeepCopy() Copier
+}
+
+type Named interface {
+	Copier
+	Name() string
+}
+
+type Drawing struct {
+	Main   Shape
+	Shapes []Shape
+	Labels map[string]Named
+	Title  Named
+}
+
+type ShapeRef struct {
+	Shape *Shape
+}
@@ -0,0 +1,8 @@
+package testdata
+
+import "io"
+
+type Readers struct {
+	Sources []io.Reader
+	Err     error
+}
@@ -0,0 +1,11 @@
+package testdata
+
+type CompositeKey struct {
+	ID    int
+	Owner *Kid
+}
+
+type Keyed struct {
+	ByKey   map[CompositeKey]string
+	ByOwner map[*Kid][]int
+}
@@ -0,0 +1,6 @@
+package testdata
+
+type Kid struct {
+	Name string
+	Kids []Kid
+}
@@ -0,0 +1,28 @@
+package testdata
+
+import "sync"
+
+// noCopy trips go vet's copylocks check when copied.
+type noCopy struct{}
+
+func (*noCopy) Lock()   {}
+func (*noCopy) Unlock() {}
+
+type Counter struct {
+	noCopy noCopy
+
+	mu     sync.Mutex
+	Counts map[string]int
+	Stats  Stats
+}
+
+type Stats struct {
+	mu   sync.RWMutex
+	wg   sync.WaitGroup
+	Hits []int
+	Name string
+}
+
+type CounterRef struct {
+	Counter *Counter
+}
@@ -0,0 +1,32 @@
+package testdata
+
+type ArrayItem struct {
+	Tags []string
+}
+
+func (i ArrayItem) DeepCopy() ArrayItem {
+	cp := i
+	if i.Tags != nil {
+		cp.Tags = make([]string, len(i.Tags))
+		copy(cp.Tags, i.Tags)
+	}
+	return cp
+}
+
+type MapArray struct {
+	Items map[string][3]ArrayItem
+	Grid  [2][]int
+}
+
+type ItemIndex struct {
+	Items map[string]*ArrayItem
+}
+
+type ArrayMaps struct {
+	Counts [2]map[string]int
+}
+
+type ItemArrays struct {
+	Items  [4]*ArrayItem
+	Labels [4]Labels
+}
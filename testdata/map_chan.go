@@ -0,0 +1,5 @@
+package testdata
+
+type ChanMap struct {
+	Chans map[string]chan int
+}
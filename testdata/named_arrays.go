@@ -0,0 +1,12 @@
+package testdata
+
+type Matrix [3][3]float64
+
+type Ptrs [4]*Bar
+
+type Grids struct {
+	Transform Matrix
+	Bars      Ptrs
+	History   []Matrix
+	Nested    map[string]Ptrs
+}
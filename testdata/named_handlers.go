@@ -0,0 +1,20 @@
+package testdata
+
+import "github.com/globusdigital/deep-copy/testdata/handlers"
+
+type NamedHandler handlers.Handler
+
+type NamedShape Shape
+
+type Routes struct {
+	Default  NamedHandler
+	ByPath   map[string]NamedHandler
+	Fallback NamedShape
+	Shapes   []NamedShape
+}
+
+type PrintHandler struct {
+	Prefix string
+}
+
+func (h PrintHandler) Handle(string) {}
@@ -0,0 +1,11 @@
+package testdata
+
+import (
+	"github.com/globusdigital/deep-copy/testdata/generic"
+	"github.com/globusdigital/deep-copy/testdata/pointer_that_implements_deepcopy/anotherpkg"
+)
+
+type NestedGeneric struct {
+	P     generic.Pair[generic.Box[int], *anotherpkg.AnotherStruct]
+	Pairs []generic.Pair[generic.Box[*Foo], generic.Box[string]]
+}
@@ -0,0 +1,7 @@
+package testdata
+
+type NilAsEmpty struct {
+	Slice  []int
+	Map    map[string]int
+	Nested map[string][]string
+}
@@ -0,0 +1,10 @@
+package testdata
+
+type PtrList struct {
+	Items []*PtrItem
+}
+
+type PtrItem struct {
+	Value int
+	Tags  []string
+}
@@ -0,0 +1,22 @@
+package testdata
+
+type NilKinds struct {
+	Slice   []int
+	Map     map[string]int
+	Pointer *int
+	Chan    chan int
+	Func    func()
+	Err     error
+	Structs map[string]NilKindsValue
+	Keys    map[NilKindsKey][]string
+}
+
+type NilKindsValue struct {
+	N    int
+	Tags []string
+}
+
+type NilKindsKey struct {
+	N    int
+	Name string
+}
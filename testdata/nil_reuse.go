@@ -0,0 +1,20 @@
+package testdata
+
+type Labels struct {
+	Names []string
+}
+
+// DeepCopy returns nil for labels without names.
+func (l *Labels) DeepCopy() *Labels {
+	if l == nil || len(l.Names) == 0 {
+		return nil
+	}
+	cp := &Labels{Names: make([]string, len(l.Names))}
+	copy(cp.Names, l.Names)
+	return cp
+}
+
+type Labeled struct {
+	Value Labels
+	Ptr   *Labels
+}
@@ -0,0 +1,12 @@
+package testdata
+
+import "database/sql"
+
+type Nullable struct {
+	Name    sql.NullString
+	Age     sql.NullInt64
+	Born    sql.NullTime
+	Aliases []sql.NullString
+	Scores  map[string]sql.NullFloat64
+	Parent  *sql.NullTime
+}
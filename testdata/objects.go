@@ -0,0 +1,24 @@
+package testdata
+
+// Object is like the runtime.Object of Kubernetes, its values are deep copied
+// by DeepCopyObject.
+type Object interface {
+	DeepCopyObject() Object
+}
+
+type ObjectList struct {
+	Items []Object
+	Owner Object
+}
+
+type Pod struct {
+	Labels map[string]string
+}
+
+func (p *Pod) DeepCopyObject() Object {
+	cp := &Pod{Labels: make(map[string]string, len(p.Labels))}
+	for k, v := range p.Labels {
+		cp.Labels[k] = v
+	}
+	return cp
+}
@@ -0,0 +1,10 @@
+package testdata
+
+type PtrSlice struct {
+	Items *[]*PtrItem
+	Maps  *map[string]*PtrItem
+}
+
+type PtrPtr struct {
+	Item **PtrItem
+}
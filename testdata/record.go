@@ -0,0 +1,8 @@
+package testdata
+
+import "github.com/globusdigital/deep-copy/testdata/driver"
+
+type Record struct {
+	Tags    driver.Array
+	Options *driver.Options
+}
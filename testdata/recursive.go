@@ -0,0 +1,25 @@
+package testdata
+
+type TreeNode struct {
+	Value       int
+	Left, Right *TreeNode
+}
+
+type BinaryTree struct {
+	Root *TreeNode
+	Size int
+}
+
+type Dept struct {
+	Head *Employee
+	Subs []*Dept
+}
+
+type Employee struct {
+	Dept   *Dept
+	Skills []string
+}
+
+type Org struct {
+	Root Dept
+}
@@ -0,0 +1,8 @@
+package testdata
+
+type RefChans struct {
+	Batches chan []int
+	Counts  chan int
+	Bars    map[string]chan *Bar
+	Sends   chan<- []int
+}
@@ -0,0 +1,3 @@
+package testdata
+
+import _ "github.com/globusdigital/deep-copy/testdata/driver"
@@ -0,0 +1,17 @@
+package testdata
+
+import (
+	"github.com/globusdigital/deep-copy/testdata/remote/keypkg"
+	legacy "github.com/globusdigital/deep-copy/testdata/remote/legacy/valpkg"
+	"github.com/globusdigital/deep-copy/testdata/remote/valpkg"
+)
+
+type Lookup struct {
+	ByKey   map[keypkg.Key]*valpkg.Val
+	Grouped map[string][]valpkg.Val
+	Legacy  map[keypkg.Key][2]*legacy.Val
+	Pending []struct {
+		Key keypkg.Key
+		Val *legacy.Val
+	}
+}
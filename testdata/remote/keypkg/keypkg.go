@@ -0,0 +1,5 @@
+package keypkg
+
+type Key struct {
+	Kind, Name string
+}
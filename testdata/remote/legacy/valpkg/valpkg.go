@@ -0,0 +1,7 @@
+// Package valpkg is named as the other valpkg, so that importing both needs
+// an alias.
+package valpkg
+
+type Val struct {
+	Tags []string
+}
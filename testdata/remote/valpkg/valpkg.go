@@ -0,0 +1,5 @@
+package valpkg
+
+type Val struct {
+	Data []byte
+}
@@ -0,0 +1,27 @@
+package testdata
+
+type ObjectMeta struct {
+	Labels map[string]string
+}
+
+func (m *ObjectMeta) DeepCopyInto(out *ObjectMeta) {
+	*out = *m
+	if m.Labels != nil {
+		out.Labels = make(map[string]string, len(m.Labels))
+		for k, v := range m.Labels {
+			out.Labels[k] = v
+		}
+	}
+}
+
+func (m *ObjectMeta) DeepCopy() *ObjectMeta {
+	out := new(ObjectMeta)
+	m.DeepCopyInto(out)
+	return out
+}
+
+type Resource struct {
+	Meta   ObjectMeta
+	Owner  *ObjectMeta
+	Extras []ObjectMeta
+}
@@ -0,0 +1,11 @@
+package testdata
+
+type Session struct {
+	ID      string
+	Roles   []string
+	Cache   map[string][]byte
+	History struct {
+		Pages []string
+		Count int
+	}
+}
@@ -0,0 +1,8 @@
+package testdata
+
+import other "github.com/globusdigital/deep-copy/testdata/samename"
+
+type SameName struct {
+	Inner *other.Inner
+	Local *Kid
+}
@@ -0,0 +1,7 @@
+// Package testdata is named as the package importing it, though its directory
+// is not.
+package testdata
+
+type Inner struct {
+	Values []int
+}
@@ -0,0 +1,8 @@
+package testdata
+
+type Sets struct {
+	Names   map[string]struct{}
+	Markers []struct{}
+	Nested  map[string]map[int]struct{}
+	Empty   struct{}
+}
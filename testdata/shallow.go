@@ -0,0 +1,13 @@
+package testdata
+
+type Shallow struct {
+	Items []int
+}
+
+type ShallowWithMethod struct {
+	Items []int
+}
+
+func (s ShallowWithMethod) ShallowCopy() ShallowWithMethod {
+	return s
+}
@@ -0,0 +1,22 @@
+package testdata
+
+type Logger struct {
+	prefix []string
+}
+
+type Service struct {
+	Name   string
+	Deps   []string
+	logger *Logger
+}
+
+type Worker struct {
+	Queue  map[string]*int
+	logger *Logger
+	Nested WorkerNested
+}
+
+type WorkerNested struct {
+	logger *Logger
+	Tags   []string
+}
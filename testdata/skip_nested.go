@@ -0,0 +1,21 @@
+package testdata
+
+type Meta struct {
+	Tags []string
+}
+
+type Store struct {
+	Entries map[string][]byte
+	Hits    []int
+}
+
+type Layer struct {
+	Meta  Meta
+	Store Store
+}
+
+type Stack struct {
+	Top    Layer
+	Bottom Layer
+	Index  map[string]Layer
+}
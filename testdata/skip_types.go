@@ -0,0 +1,20 @@
+package testdata
+
+import (
+	"sync"
+	"time"
+)
+
+// Symbol values are interned, so their copies share them.
+type Symbol struct {
+	Name *string
+}
+
+type Ledger struct {
+	mu      sync.Mutex
+	once    sync.Once
+	Owner   Symbol
+	Symbols []Symbol
+	Entries map[string][]int
+	Created time.Time
+}
@@ -0,0 +1,7 @@
+package testdata
+
+type Book struct {
+	Title  string
+	Tags   []string
+	Sequel *Book
+}
@@ -0,0 +1,15 @@
+package testdata
+
+import "time"
+
+type Snapshot struct {
+	Name   string
+	Tags   []string
+	Scores map[string][]int
+	Owner  *Kid
+	Kids   []Kid
+	Parent *Snapshot
+	Taken  time.Time
+	Meta   any
+	Grid   [2][]int
+}
@@ -0,0 +1,10 @@
+package testdata
+
+// DeepCopy is declared apart from Split, in another file of the package.
+func (s Split) DeepCopy() Split {
+	cp := s
+	if s.Items != nil {
+		cp.Items = append([]int(nil), s.Items...)
+	}
+	return cp
+}
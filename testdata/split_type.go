@@ -0,0 +1,10 @@
+package testdata
+
+type Split struct {
+	Items []int
+}
+
+type SplitHolder struct {
+	Parts []Split
+	Part  *Split
+}
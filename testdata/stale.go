@@ -0,0 +1,7 @@
+package testdata
+
+type Stale struct {
+	Items []string
+	Next  *Stale
+	Added map[string][]int
+}
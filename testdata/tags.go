@@ -0,0 +1,16 @@
+package testdata
+
+import "github.com/globusdigital/deep-copy/testdata/generic"
+
+type Tagged struct {
+	ID      int              `deepcopy:"zero"`
+	Name    string           `deepcopy:"zero"`
+	Active  bool             `deepcopy:"zero"`
+	Stamp   Baz              `deepcopy:"zero"`
+	Box     generic.Box[int] `deepcopy:"zero"`
+	Cache   []string         `deepcopy:"zero"`
+	Index   map[string]*int  `json:"index" deepcopy:"zero"`
+	Shared  []int            `deepcopy:"skip"`
+	Copied  []int
+	Ignored map[string]string `json:"ignored"`
+}
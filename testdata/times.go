@@ -0,0 +1,14 @@
+package testdata
+
+import "time"
+
+type Schedule struct {
+	Start time.Time
+	Dates []time.Time
+	Due   map[string]time.Time
+}
+
+type MyTime struct {
+	time.Time
+	Zones []string
+}
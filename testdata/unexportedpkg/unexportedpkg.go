@@ -0,0 +1,8 @@
+// Package unexportedpkg declares a type with unexported members, which the
+// generated code of other packages copies shallowly.
+package unexportedpkg
+
+type WithUnexported struct {
+	Exported   []int
+	unexported []int
+}
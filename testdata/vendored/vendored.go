@@ -0,0 +1,8 @@
+package vendored
+
+import dep "example.com/go-dep"
+
+type Vendored struct {
+	Item  dep.Item
+	Items map[string][]*dep.Item
+}
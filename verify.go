@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// verifyName is the name of the file the generated content is type checked
+// in, when it isn't written to an output file.
+const verifyName = "deep_copy_verify_gen"
+
+// verifyCompiles type checks the package of the path, with the generated
+// content in place of the output file, or in a new file when the output is
+// empty. The errors are reported together with the offending generated lines.
+func verifyCompiles(path, output string, generated []byte, tests bool) error {
+	all, err := load(path, tests)
+	if err != nil {
+		return fmt.Errorf("loading package: %v", err)
+	}
+	if len(all) == 0 || len(all[0].GoFiles) == 0 {
+		return errors.New("no package found")
+	}
+
+	// The types of test files are only visible to other test files.
+	name := verifyName + ".go"
+	if tests {
+		name = verifyName + "_test.go"
+	}
+
+	file := filepath.Join(filepath.Dir(all[0].GoFiles[0]), name)
+	if output != "" {
+		if file, err = filepath.Abs(output); err != nil {
+			return err
+		}
+	}
+
+	cfg, patterns := loadConfig(path, tests)
+	cfg.Overlay = map[string][]byte{file: generated}
+
+	all, err = packages.Load(cfg, patterns)
+	if err != nil {
+		return fmt.Errorf("loading package: %v", err)
+	}
+
+	lines := bytes.Split(generated, []byte("\n"))
+
+	var msgs []string
+	seen := map[string]struct{}{}
+	for _, p := range all {
+		for _, e := range p.Errors {
+			msg := e.Error()
+			if _, ok := seen[msg]; ok {
+				continue
+			}
+			seen[msg] = struct{}{}
+
+			if n := errorLine(e, file); n > 0 && n <= len(lines) {
+				msg += "\n\t" + strings.TrimSpace(string(lines[n-1]))
+			}
+			msgs = append(msgs, msg)
+		}
+	}
+
+	if len(msgs) > 0 {
+		return fmt.Errorf("generated code doesn't compile:\n%s", strings.Join(msgs, "\n"))
+	}
+
+	return nil
+}
+
+// errorLine returns the line of the error in the file, or 0 if it is located
+// elsewhere.
+func errorLine(e packages.Error, file string) int {
+	pos := strings.TrimPrefix(e.Pos, file+":")
+	if pos == e.Pos {
+		return 0
+	}
+
+	n, err := strconv.Atoi(strings.SplitN(pos, ":", 2)[0])
+	if err != nil {
+		return 0
+	}
+
+	return n
+}
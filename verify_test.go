@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func Test_verifyCompiles(t *testing.T) {
+	a := &app{quiet: true}
+	generated, err := a.run("./testdata", typesVal{"Alpha"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := verifyCompiles("./testdata", "", generated, false); err != nil {
+		t.Errorf("verifyCompiles() error = %v", err)
+	}
+}
+
+func Test_verifyCompiles_output(t *testing.T) {
+	// The output file is replaced, its methods aren't declared twice.
+	generated, err := os.ReadFile("./testdata/foo_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := verifyCompiles("./testdata", "./testdata/foo_gen.go", generated, false); err != nil {
+		t.Errorf("verifyCompiles() error = %v", err)
+	}
+}
+
+func Test_verifyCompiles_errors(t *testing.T) {
+	generated := []byte(`// generated by deep-copy; DO NOT EDIT.
+
+package testdata
+
+// DeepCopy generates a deep copy of Alpha
+func (o Alpha) DeepCopy() Alpha {
+	var cp Alpha = o
+	cp.Missing = nil
+	return cp
+}
+`)
+
+	err := verifyCompiles("./testdata", "", generated, false)
+	if err == nil {
+		t.Fatal("verifyCompiles() error = nil, want an error")
+	}
+	if !strings.Contains(err.Error(), "\n\tcp.Missing = nil") {
+		t.Errorf("verifyCompiles() error = %v, want the offending line", err)
+	}
+}